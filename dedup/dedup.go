@@ -0,0 +1,143 @@
+package dedup
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultChanged is the Changed window of the sources that do not configure one
+	DefaultChanged = time.Minute
+	// DefaultReannounce is the Reannounce window of the sources that do not configure one
+	DefaultReannounce = 10 * time.Minute
+)
+
+// Window holds how often the results of a source are announced
+type Window struct {
+	// Changed is the minimum time between two announcements of a result that changed
+	Changed time.Duration `mapstructure:"changed"`
+	// Reannounce is the time after which a result that did not change is announced again
+	Reannounce time.Duration `mapstructure:"reannounce"`
+}
+
+// SetDefaults fills the durations of the window that are not configured
+func (w *Window) SetDefaults() {
+	if w.Changed == 0 {
+		w.Changed = DefaultChanged
+	}
+	if w.Reannounce == 0 {
+		w.Reannounce = DefaultReannounce
+	}
+}
+
+// Key identifies the results that are compared with each other, a source may
+// offer several appointment types for the same vaccine, like a first and a
+// second dose, which are told apart by their dose and detail
+type Key struct {
+	Source  string
+	Vaccine string
+	Dose    string
+	Detail  string
+}
+
+// Announcement is the last announcement of the result of a Key
+type Announcement struct {
+	Fingerprint string
+	At          time.Time
+}
+
+// Store persists the announcements so a restart does not announce everything again
+type Store interface {
+	Save(source, vaccine, dose, detail, fingerprint string, announcedAt time.Time) error
+}
+
+// Policy decides which results are worth announcing, a result is announced
+// when it changed since its last announcement and the Changed window is over,
+// or when the Reannounce window is over
+type Policy struct {
+	now   func() time.Time
+	store Store
+
+	mu   sync.Mutex
+	last map[Key]Announcement
+}
+
+// New returns a new Policy saving its announcements in the store
+func New(store Store) *Policy {
+	return &Policy{
+		now:   time.Now,
+		store: store,
+		last:  make(map[Key]Announcement),
+	}
+}
+
+// Restore sets the last announcement of a key, it is used to load the
+// announcements saved before a restart
+func (p *Policy) Restore(key Key, announcement Announcement) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.last[key] = announcement
+}
+
+// Filter returns the results of the source that should be announced now and
+// records them as announced
+func (p *Policy) Filter(source string, window Window, results []*vaccines.Result) []*vaccines.Result {
+	ret, announced := p.filter(source, window, results)
+
+	for key, announcement := range announced {
+		err := p.store.Save(key.Source, key.Vaccine, key.Dose, key.Detail, announcement.Fingerprint, announcement.At)
+		if err != nil {
+			// the result is announced anyway, at worst it is announced again after a restart
+			log.Warnf("%s: could not save the announcement for %s: %v", key.Source, key.Vaccine, err)
+		}
+	}
+	return ret
+}
+
+func (p *Policy) filter(source string, window Window, results []*vaccines.Result) ([]*vaccines.Result, map[Key]Announcement) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var ret []*vaccines.Result
+	announced := make(map[Key]Announcement)
+	for _, result := range results {
+		key := Key{Source: source, Vaccine: string(result.Vaccine), Dose: string(result.Dose), Detail: result.Detail}
+		current := Announcement{Fingerprint: fingerprint(result), At: now}
+
+		if last, ok := p.last[key]; ok && !shouldAnnounce(last, current, window) {
+			continue
+		}
+		p.last[key] = current
+		announced[key] = current
+		ret = append(ret, result)
+	}
+	return ret, announced
+}
+
+func shouldAnnounce(last, current Announcement, window Window) bool {
+	elapsed := current.At.Sub(last.At)
+	if last.Fingerprint != current.Fingerprint && elapsed >= window.Changed {
+		return true
+	}
+	return elapsed >= window.Reannounce
+}
+
+// fingerprint summarizes what makes a result different from another one, the
+// time it was fetched at is left out on purpose
+func fingerprint(result *vaccines.Result) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s|%s|%d|%s|%t", result.Vaccine, result.Dose, result.Amount, result.Detail, result.DateOnly)
+	for _, slot := range result.Slots {
+		fmt.Fprintf(h, "|%d", slot.Unix())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
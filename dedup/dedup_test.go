@@ -0,0 +1,150 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// memoryStore records the announcements saved by the policy
+type memoryStore struct {
+	saved []Key
+}
+
+func (s *memoryStore) Save(source, vaccine, dose, detail, fingerprint string, announcedAt time.Time) error {
+	s.saved = append(s.saved, Key{Source: source, Vaccine: vaccine, Dose: dose, Detail: detail})
+	return nil
+}
+
+var testWindow = Window{Changed: time.Minute, Reannounce: 10 * time.Minute}
+
+func TestShouldAnnounce(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		last    Announcement
+		current Announcement
+		want    bool
+	}{
+		{
+			name:    "unchanged within the reannounce window",
+			last:    Announcement{Fingerprint: "a", At: start},
+			current: Announcement{Fingerprint: "a", At: start.Add(9 * time.Minute)},
+			want:    false,
+		},
+		{
+			name:    "unchanged after the reannounce window",
+			last:    Announcement{Fingerprint: "a", At: start},
+			current: Announcement{Fingerprint: "a", At: start.Add(10 * time.Minute)},
+			want:    true,
+		},
+		{
+			name:    "changed within the changed window",
+			last:    Announcement{Fingerprint: "a", At: start},
+			current: Announcement{Fingerprint: "b", At: start.Add(30 * time.Second)},
+			want:    false,
+		},
+		{
+			name:    "changed after the changed window",
+			last:    Announcement{Fingerprint: "a", At: start},
+			current: Announcement{Fingerprint: "b", At: start.Add(time.Minute)},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldAnnounce(tt.last, tt.current, testWindow); got != tt.want {
+				t.Errorf("shouldAnnounce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	first := func() *vaccines.Result {
+		return &vaccines.Result{Vaccine: vaccines.Pfizer, Dose: vaccines.DoseFirst, Amount: 3, Detail: "BioNTech Erstimpfung"}
+	}
+	second := func() *vaccines.Result {
+		return &vaccines.Result{Vaccine: vaccines.Pfizer, Dose: vaccines.DoseSecond, Amount: 5, Detail: "BioNTech Zweitimpfung"}
+	}
+	changed := func() *vaccines.Result {
+		result := first()
+		result.Amount = 4
+		return result
+	}
+
+	type round struct {
+		after   time.Duration
+		results []*vaccines.Result
+		want    int
+	}
+	tests := []struct {
+		name    string
+		restore map[Key]Announcement
+		rounds  []round
+	}{
+		{
+			name: "same vaccine with two doses",
+			rounds: []round{
+				{after: 0, results: []*vaccines.Result{first(), second()}, want: 2},
+				{after: 2 * time.Minute, results: []*vaccines.Result{first(), second()}, want: 0},
+				{after: 4 * time.Minute, results: []*vaccines.Result{first(), second()}, want: 0},
+			},
+		},
+		{
+			name: "unchanged result",
+			rounds: []round{
+				{after: 0, results: []*vaccines.Result{first()}, want: 1},
+				{after: 5 * time.Minute, results: []*vaccines.Result{first()}, want: 0},
+				{after: 10 * time.Minute, results: []*vaccines.Result{first()}, want: 1},
+			},
+		},
+		{
+			name: "changed result",
+			rounds: []round{
+				{after: 0, results: []*vaccines.Result{first()}, want: 1},
+				{after: 30 * time.Second, results: []*vaccines.Result{changed()}, want: 0},
+				{after: time.Minute, results: []*vaccines.Result{changed()}, want: 1},
+			},
+		},
+		{
+			name: "restored announcement",
+			restore: map[Key]Announcement{
+				{Source: "practice", Vaccine: string(vaccines.Pfizer), Dose: string(vaccines.DoseFirst), Detail: "BioNTech Erstimpfung"}: {
+					Fingerprint: fingerprint(first()),
+					At:          start.Add(-5 * time.Minute),
+				},
+			},
+			rounds: []round{
+				{after: 0, results: []*vaccines.Result{first()}, want: 0},
+				{after: 5 * time.Minute, results: []*vaccines.Result{first()}, want: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryStore{}
+			policy := New(store)
+			now := start
+			policy.now = func() time.Time { return now }
+			for key, announcement := range tt.restore {
+				policy.Restore(key, announcement)
+			}
+
+			saved := 0
+			for i, r := range tt.rounds {
+				now = start.Add(r.after)
+				got := policy.Filter("practice", testWindow, r.results)
+				if len(got) != r.want {
+					t.Errorf("round %d: Filter() returned %d results, want %d", i, len(got), r.want)
+				}
+				saved += r.want
+			}
+			if len(store.saved) != saved {
+				t.Errorf("%d announcements saved, want %d", len(store.saved), saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/geo"
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+	"github.com/eleboucher/berlin-vaccine-alert/models/chat"
+	"github.com/eleboucher/berlin-vaccine-alert/models/dialog"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// dialogTimeout is how long a question waits for its answer
+const dialogTimeout = 10 * time.Minute
+
+// the steps of the dialogs, each step is a question
+const (
+	stepPostcode   = "postcode"
+	stepDistrict   = "district"
+	stepDays       = "days"
+	stepSlots      = "slots"
+	stepQuiet      = "quiet"
+	stepTimezone   = "timezone"
+	stepDigest     = "digest"
+	stepMinAmount  = "min"
+	stepMinVaccine = "min-vaccine"
+)
+
+// invalidAnswer is returned by the steps when the answer can't be used, the
+// question is asked again after the reason
+type invalidAnswer string
+
+func (e invalidAnswer) Error() string {
+	return string(e)
+}
+
+// dialogStep is a question asked to a chat
+type dialogStep struct {
+	// page is the settings page the question is about
+	page string
+	// prompt asks the question in the language
+	prompt func(language locales.Language) string
+	// answer validates and applies the answer, the data holds the answers of
+	// the previous steps and can be added to. It returns the next step, or the
+	// empty string when the dialog is over.
+	answer func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error)
+}
+
+// translated returns a prompt asking the question written in English
+func translated(question string) func(language locales.Language) string {
+	return func(language locales.Language) string {
+		return language.Translate(question)
+	}
+}
+
+// dialogSteps are the steps the dialogs are made of
+var dialogSteps = map[string]dialogStep{
+	stepPostcode: {
+		page:   pageWhere,
+		prompt: translated("What is your postcode?"),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			place, err := geo.Locate(text)
+			if err != nil {
+				return "", invalidAnswer(language.Sprintf("%q is not a Berlin postcode", text))
+			}
+			return "", t.setLocation(chatID, place.Point, language.Sprintf("your location is set to %s (%s)", place.Postcode, place.District))
+		},
+	},
+	stepDistrict: {
+		page: pageWhere,
+		prompt: func(language locales.Language) string {
+			return language.Sprintf("Which district do you look for? One of:\n%s", strings.Join(geo.Districts(), "\n"))
+		},
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			if _, err := geo.ParseDistrict(text); err != nil {
+				return "", invalidAnswer(language.Sprintf("%q is not a Berlin district", text))
+			}
+			return "", t.addDistrict(chatID, language, text)
+		},
+	},
+	stepDays: {
+		page:   pageWhen,
+		prompt: translated("How many days ahead do you look for appointments? Send a number, 0 for any date"),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			days, err := strconv.Atoi(text)
+			if err != nil || days < 0 {
+				return "", invalidAnswer(language.Sprintf("%q is not a number of days", text))
+			}
+			return "", t.setDays(chatID, days)
+		},
+	},
+	stepSlots: {
+		page:   pageTimes,
+		prompt: translated("When can you go to an appointment? Send days and an optional time range, like \"mon-fri 17:00-24:00\", \"weekend\" or \"sat 10:00-14:00\""),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			if _, err := chat.ParseSlotWindow(text); err != nil {
+				return "", invalidAnswer(language.Sprintf("%q are not days and hours I understand", text))
+			}
+			return "", t.addSlotWindow(chatID, language, text)
+		},
+	},
+	stepQuiet: {
+		page:   pageQuiet,
+		prompt: translated("When do you not want to be disturbed? Send hours like \"22:00-07:00\", or \"off\""),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			if isWord(language, text, "off") {
+				return "", t.setQuietHours(chatID, nil)
+			}
+			quietHours, err := chat.ParseQuietHours(text)
+			if err != nil {
+				return "", invalidAnswer(language.Sprintf("%q are not hours I understand", text))
+			}
+			return "", t.setQuietHours(chatID, &quietHours)
+		},
+	},
+	stepTimezone: {
+		page:   pageQuiet,
+		prompt: translated("What is your timezone? Send its name like \"Europe/Berlin\""),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			if _, err := time.LoadLocation(text); err != nil || text == "" {
+				return "", invalidAnswer(language.Sprintf("%q is not a timezone I know", text))
+			}
+			return "", t.setTimezone(chatID, text)
+		},
+	},
+	stepDigest: {
+		page: pageDelivery,
+		prompt: func(language locales.Language) string {
+			return language.Sprintf("How often do you want a digest? Send a duration of at least %s like \"90m\" or \"6h\", or \"off\" for instant alerts", minDigestInterval)
+		},
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			if isWord(language, text, "off") {
+				return "", t.setDigestInterval(chatID, language, 0)
+			}
+			interval, err := time.ParseDuration(text)
+			if err != nil || interval < minDigestInterval {
+				return "", invalidAnswer(language.Sprintf("%q is not a duration of at least %s", text, minDigestInterval))
+			}
+			return "", t.setDigestInterval(chatID, language, interval)
+		},
+	},
+	stepMinAmount: {
+		page:   pageAmount,
+		prompt: translated("How many free slots does an alert need before you receive it? Send a number, 0 for any amount"),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			amount, err := strconv.Atoi(text)
+			if err != nil || amount < 0 {
+				return "", invalidAnswer(language.Sprintf("%q is not a number of slots", text))
+			}
+			data["amount"] = text
+			return stepMinVaccine, nil
+		},
+	},
+	stepMinVaccine: {
+		page:   pageAmount,
+		prompt: translated("For which vaccine? Send a vaccine like \"pfizer\" or \"mrna\", or \"all\" for every vaccine"),
+		answer: func(t *Telegram, chatID int64, language locales.Language, text string, data map[string]string) (string, error) {
+			amount, err := strconv.Atoi(data["amount"])
+			if err != nil {
+				return "", err
+			}
+			var vaccine vaccines.Vaccine
+			if !isWord(language, text, "all") {
+				vaccine, err = vaccines.Parse(text)
+				if err != nil {
+					return "", invalidAnswer(language.Sprintf("%q is not a vaccine I know", text))
+				}
+			}
+			return "", t.setMinAmount(chatID, string(vaccine), amount)
+		},
+	},
+}
+
+// askDialog asks the question of the step to the chat in the language, the next
+// message of the chat answers it unless it is a command or a button. The chats
+// that did not send /start yet are asked to send it first.
+func (t *Telegram) askDialog(chatID int64, language locales.Language, step string, data map[string]string) error {
+	s, ok := dialogSteps[step]
+	if !ok {
+		return fmt.Errorf("unknown dialog step %q", step)
+	}
+	// the dialogs belong to the chats, which only exist once they sent /start
+	_, err := t.chatModel.Find(chatID)
+	if err != nil {
+		if errors.Is(err, chat.ErrChatNotFound) {
+			_, err = t.bot.Send(tgbotapi.NewMessage(chatID, language.Translate(startFirstMessage)))
+		}
+		return err
+	}
+
+	_, err = t.dialogModel.Set(chatID, step, data, time.Now().Add(dialogTimeout))
+	if err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, s.prompt(language)+"\n\n"+language.Translate("Send /cancel to stop"))
+	msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true}
+	_, err = t.bot.Send(msg)
+	return err
+}
+
+// answerDialog gives the text to the pending dialog of the chat, it reports
+// whether there was one
+func (t *Telegram) answerDialog(chatID int64, language locales.Language, text string) (bool, error) {
+	d, err := t.dialogModel.Find(chatID, time.Now())
+	if err != nil {
+		if errors.Is(err, dialog.ErrDialogNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	step, ok := dialogSteps[d.Step]
+	if !ok {
+		// the step was removed since the question was asked
+		return false, t.dialogModel.Delete(chatID)
+	}
+	data := d.Data
+	if data == nil {
+		data = make(map[string]string)
+	}
+	next, err := step.answer(t, chatID, language, strings.TrimSpace(text), data)
+	var invalid invalidAnswer
+	if errors.As(err, &invalid) {
+		err = t.SendMessage(invalid.Error(), chatID)
+		if err != nil {
+			return true, err
+		}
+		return true, t.askDialog(chatID, language, d.Step, d.Data)
+	}
+	if err != nil {
+		deleteErr := t.dialogModel.Delete(chatID)
+		if deleteErr != nil {
+			log.Error(deleteErr)
+		}
+		return true, err
+	}
+	if next != "" {
+		return true, t.askDialog(chatID, language, next, data)
+	}
+	return true, t.dialogModel.Delete(chatID)
+}
+
+// cancelDialog ends the pending dialog of the chat
+func (t *Telegram) cancelDialog(chatID int64, language locales.Language) error {
+	_, err := t.dialogModel.Find(chatID, time.Now())
+	if err != nil {
+		if errors.Is(err, dialog.ErrDialogNotFound) {
+			return t.SendMessage(language.Translate("there is no question to cancel"), chatID)
+		}
+		return err
+	}
+	err = t.dialogModel.Delete(chatID)
+	if err != nil {
+		return err
+	}
+	return t.SendMessage(language.Translate("cancelled, nothing was changed"), chatID)
+}
+
+// ExpireDialogs drops the questions left unanswered for too long and tells
+// their chat, until the context is canceled
+func (t *Telegram) ExpireDialogs(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("done with dialogs")
+			return
+		case now := <-ticker.C:
+			err := t.expireDialogs(now)
+			if err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+// expireDialogs drops the questions expired at now
+func (t *Telegram) expireDialogs(now time.Time) error {
+	dialogs, err := t.dialogModel.ListExpired(now)
+	if err != nil {
+		return err
+	}
+	for _, d := range dialogs {
+		language := locales.English
+		subscriber, err := t.chatModel.Find(d.ChatID)
+		if err != nil {
+			log.Error(err)
+		} else {
+			language = subscriber.Language
+		}
+		err = t.SendMessage(language.Translate("⌛ I stopped waiting for your answer, nothing was changed"), d.ChatID)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+	return t.dialogModel.DeleteExpired(now)
+}
+
+// isWord reports whether the answer is the word the question asked to send, in
+// English or in the language
+func isWord(language locales.Language, text string, word string) bool {
+	return strings.EqualFold(text, word) || strings.EqualFold(text, language.Translate(word))
+}
+
+// isKeyboardButton reports whether the text is one of the buttons of the reply
+// keyboard, which are never taken as the answer of a dialog
+func isKeyboardButton(text string) bool {
+	switch text {
+	case "open", "close", startButton, stopButton, settingsButton, contributeButton, filterButton, infoFilterButton, backButton:
+		return true
+	}
+	_, ok := legacyVaccineButtons[text]
+	return ok
+}
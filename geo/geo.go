@@ -0,0 +1,144 @@
+// Package geo locates the Berlin postcodes and districts without any
+// geocoding service, from a table embedded in the binary.
+package geo
+
+import (
+	_ "embed" // embeds the postcode table
+	"encoding/csv"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// postcodesCSV holds the approximate centre and the district of every Berlin
+// postcode
+//
+//go:embed postcodes.csv
+var postcodesCSV string
+
+var (
+	// ErrPostcodeNotFound is return when the postcode is not a Berlin postcode
+	ErrPostcodeNotFound = errors.New("postcode not found")
+	// ErrDistrictNotFound is return when the district is not a Berlin district
+	ErrDistrictNotFound = errors.New("district not found")
+)
+
+// Point is a position on the earth
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Place is a Berlin postcode
+type Place struct {
+	Postcode string
+	District string
+	Point
+}
+
+// neighbourhoods maps the usual names of the former districts and
+// neighbourhoods to their district
+var neighbourhoods = map[string]string{
+	"tiergarten":        "Mitte",
+	"moabit":            "Mitte",
+	"wedding":           "Mitte",
+	"gesundbrunnen":     "Mitte",
+	"prenzlauer berg":   "Pankow",
+	"weißensee":         "Pankow",
+	"weissensee":        "Pankow",
+	"schöneberg":        "Tempelhof-Schöneberg",
+	"schoeneberg":       "Tempelhof-Schöneberg",
+	"friedenau":         "Tempelhof-Schöneberg",
+	"tempelhof":         "Tempelhof-Schöneberg",
+	"steglitz":          "Steglitz-Zehlendorf",
+	"zehlendorf":        "Steglitz-Zehlendorf",
+	"lichterfelde":      "Steglitz-Zehlendorf",
+	"dahlem":            "Steglitz-Zehlendorf",
+	"charlottenburg":    "Charlottenburg-Wilmersdorf",
+	"wilmersdorf":       "Charlottenburg-Wilmersdorf",
+	"friedrichshain":    "Friedrichshain-Kreuzberg",
+	"kreuzberg":         "Friedrichshain-Kreuzberg",
+	"treptow":           "Treptow-Köpenick",
+	"köpenick":          "Treptow-Köpenick",
+	"koepenick":         "Treptow-Köpenick",
+	"marzahn":           "Marzahn-Hellersdorf",
+	"hellersdorf":       "Marzahn-Hellersdorf",
+	"hohenschönhausen":  "Lichtenberg",
+	"hohenschoenhausen": "Lichtenberg",
+	"neukoelln":         "Neukölln",
+}
+
+var (
+	places    = loadPlaces()
+	districts = listDistricts()
+)
+
+func loadPlaces() map[string]Place {
+	records, err := csv.NewReader(strings.NewReader(postcodesCSV)).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+	places := make(map[string]Place, len(records))
+	for _, record := range records[1:] {
+		latitude, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			panic(err)
+		}
+		longitude, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			panic(err)
+		}
+		places[record[0]] = Place{
+			Postcode: record[0],
+			District: record[1],
+			Point:    Point{Latitude: latitude, Longitude: longitude},
+		}
+	}
+	return places
+}
+
+func listDistricts() []string {
+	seen := make(map[string]bool)
+	var districts []string
+	for _, place := range places {
+		if !seen[place.District] {
+			seen[place.District] = true
+			districts = append(districts, place.District)
+		}
+	}
+	sort.Strings(districts)
+	return districts
+}
+
+// Locate returns the place of a Berlin postcode
+func Locate(postcode string) (Place, error) {
+	place, ok := places[strings.TrimSpace(postcode)]
+	if !ok {
+		return Place{}, ErrPostcodeNotFound
+	}
+	return place, nil
+}
+
+// Districts returns the Berlin districts sorted by name
+func Districts() []string {
+	return append([]string(nil), districts...)
+}
+
+// ParseDistrict returns the district named in the text, which can be the name
+// of the district, one of its halves like "Kreuzberg" or a former district
+func ParseDistrict(text string) (string, error) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if lower == "" {
+		return "", ErrDistrictNotFound
+	}
+	for _, district := range districts {
+		if strings.ToLower(district) == lower {
+			return district, nil
+		}
+	}
+	if district, ok := neighbourhoods[lower]; ok {
+		return district, nil
+	}
+	return "", ErrDistrictNotFound
+}
@@ -0,0 +1,70 @@
+package geo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name         string
+		postcode     string
+		wantDistrict string
+		wantErr      error
+	}{
+		{name: "postcode", postcode: "10115", wantDistrict: "Mitte"},
+		{name: "postcode with spaces", postcode: " 12049\n", wantDistrict: "Neukölln"},
+		// 10965 covers Kreuzberg and a part of Tempelhof, it is given to the
+		// district holding most of it
+		{name: "postcode covering two districts", postcode: "10965", wantDistrict: "Friedrichshain-Kreuzberg"},
+		{name: "postcode outside Berlin", postcode: "80331", wantErr: ErrPostcodeNotFound},
+		{name: "not a postcode", postcode: "Mitte", wantErr: ErrPostcodeNotFound},
+		{name: "empty", postcode: "", wantErr: ErrPostcodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			place, err := Locate(tt.postcode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Locate(%q) error = %v, want %v", tt.postcode, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if place.District != tt.wantDistrict {
+				t.Errorf("Locate(%q) district = %q, want %q", tt.postcode, place.District, tt.wantDistrict)
+			}
+			if place.Latitude == 0 || place.Longitude == 0 {
+				t.Errorf("Locate(%q) has no coordinates", tt.postcode)
+			}
+		})
+	}
+}
+
+func TestParseDistrict(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    string
+		wantErr error
+	}{
+		{text: "Mitte", want: "Mitte"},
+		{text: "mitte", want: "Mitte"},
+		{text: "FRIEDRICHSHAIN-KREUZBERG", want: "Friedrichshain-Kreuzberg"},
+		{text: "  neukölln ", want: "Neukölln"},
+		{text: "Kreuzberg", want: "Friedrichshain-Kreuzberg"},
+		{text: "TEMPELHOF", want: "Tempelhof-Schöneberg"},
+		{text: "Prenzlauer Berg", want: "Pankow"},
+		{text: "Potsdam", wantErr: ErrDistrictNotFound},
+		{text: "", wantErr: ErrDistrictNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseDistrict(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseDistrict(%q) error = %v, want %v", tt.text, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDistrict(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
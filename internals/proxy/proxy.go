@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -48,15 +49,20 @@ type Websites struct {
 
 var limiter = rate.NewLimiter(rate.Every(2*time.Second), 1)
 
-var ctx = context.Background()
+var client = &http.Client{Timeout: 30 * time.Second}
 
+// Proxy holds the proxy shared by the fetches of a host, it is safe for
+// concurrent use
 type Proxy struct {
+	mu     sync.Mutex
 	IPPort string
 }
 
-func (p *Proxy) Proxy() string {
+func (p *Proxy) Proxy(ctx context.Context) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if p.IPPort == "" {
-		ipPort, err := fetchProxy()
+		ipPort, err := fetchProxy(ctx)
 		if err != nil {
 			logrus.Error(err)
 		}
@@ -65,22 +71,27 @@ func (p *Proxy) Proxy() string {
 	return p.IPPort
 }
 
-func (p *Proxy) RenewProxy() {
-	ipPort, err := fetchProxy()
+func (p *Proxy) RenewProxy(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ipPort, err := fetchProxy(ctx)
 	if err != nil {
 		logrus.Error(err)
 	}
 	p.IPPort = ipPort
 }
 
-func fetchProxy() (string, error) {
+func fetchProxy(ctx context.Context) (string, error) {
 	url := "https://gimmeproxy.com/api/getProxy?user-agent=true&supportsHttps=true&protocol=http"
 	err := limiter.Wait(ctx)
 	if err != nil {
 		return "", err
 	}
-	req, _ := http.NewRequest("GET", url, nil)
-	res, err := http.DefaultClient.Do(req)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := client.Do(req)
 	if err != nil {
 
 		return "", err
@@ -0,0 +1,264 @@
+package locales
+
+// german is the catalogue of the German messages
+var german = map[string]string{
+	// the reply keyboard
+	"Start":                  "Start",
+	"Stop":                   "Stopp",
+	"Settings":               "Einstellungen",
+	"Share my location":      "Meinen Standort teilen",
+	"Contribute and support": "Mitmachen und unterstützen",
+	"Info about filters":     "Infos zu den Filtern",
+	"Back":                   "Zurück",
+
+	// the welcome, goodbye and contribute messages
+	`
+Welcome 👋🏼!
+You are now added to the subscription list, you will receive appointments shortly when they will be available
+I hope this bot helps you in your research to get the vaccine!
+
+Provide feedback 📢 on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Feel free to help me with the cost of the bot or with the code, via:
+💸 Donate via PayPal: https://paypal.me/ELeboucher
+🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+
+I really hope it can help you to find your appointment!
+
+Stay Safe, and thanks for your support! ❤️`: `
+Willkommen 👋🏼!
+Du bist jetzt auf der Liste und bekommst Termine, sobald welche verfügbar sind
+Ich hoffe, dieser Bot hilft dir bei deiner Suche nach einer Impfung!
+
+Gib Feedback 📢 auf Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Hilf mir gern bei den Kosten des Bots oder beim Code, über:
+💸 Spenden über PayPal: https://paypal.me/ELeboucher
+🍻 Spendier mir ein Bier: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Zum Code beitragen:  https://github.com/eleboucher/berlin-vaccine-alert
+
+Ich hoffe wirklich, dass er dir hilft, deinen Termin zu finden!
+
+Bleib gesund und danke für deine Unterstützung! ❤️`,
+	`
+Hey Again!
+You are already added to the subscription list, you will receive appointments shortly when they will be available!
+
+I hope this bot helps you in your research to get the vaccine!
+
+Provide feedback 📢 on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Feel free to help me with the cost or with the code, via:
+💸 Donate via PayPal: https://paypal.me/ELeboucher
+🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+
+I really hope it can help you to find your appointment!
+
+Stay Safe, and thanks for your support! ❤️`: `
+Hallo nochmal!
+Du bist schon auf der Liste und bekommst Termine, sobald welche verfügbar sind!
+
+Ich hoffe, dieser Bot hilft dir bei deiner Suche nach einer Impfung!
+
+Gib Feedback 📢 auf Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Hilf mir gern bei den Kosten oder beim Code, über:
+💸 Spenden über PayPal: https://paypal.me/ELeboucher
+🍻 Spendier mir ein Bier: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Zum Code beitragen:  https://github.com/eleboucher/berlin-vaccine-alert
+
+Ich hoffe wirklich, dass er dir hilft, deinen Termin zu finden!
+
+Bleib gesund und danke für deine Unterstützung! ❤️`,
+	`
+Hey!
+
+You are removed from the list. If you want to receive messages again type /start.
+
+I hope you had book an appointment and you are getting vaccinated soon!
+
+If you have any feedback feel free to post something on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Feel free to help me with the cost of the bot or with the code, via:
+💸 Donate via PayPal: https://paypal.me/ELeboucher
+🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+
+Stay Safe, and thanks for your support! ❤️`: `
+Hey!
+
+Du bist jetzt von der Liste entfernt. Wenn du wieder Nachrichten bekommen willst, schreib /start.
+
+Ich hoffe, du hast einen Termin gebucht und wirst bald geimpft!
+
+Wenn du Feedback hast, schreib gern etwas auf Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Hilf mir gern bei den Kosten des Bots oder beim Code, über:
+💸 Spenden über PayPal: https://paypal.me/ELeboucher
+🍻 Spendier mir ein Bier: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Zum Code beitragen:  https://github.com/eleboucher/berlin-vaccine-alert
+
+Bleib gesund und danke für deine Unterstützung! ❤️`,
+	"Hey you 🚀,\nThanks a lot for using the bot,\n\n\nFeel free to contribute on Github: https://github.com/eleboucher/berlin-vaccine-alert\n\n\nOr feel free to contribute on Paypal https://paypal.me/ELeboucher or Buy me a beer https://www.buymeacoffee.com/eleboucher": "Hey du 🚀,\nvielen Dank, dass du den Bot benutzt,\n\n\nDu kannst gern auf Github beitragen: https://github.com/eleboucher/berlin-vaccine-alert\n\n\nOder über Paypal https://paypal.me/ELeboucher oder spendier mir ein Bier https://www.buymeacoffee.com/eleboucher",
+
+	// the commands
+	"your location is saved":                                   "dein Standort ist gespeichert",
+	"your location is set to %s (%s)":                          "dein Standort ist %s (%s)",
+	", choose the maximum distance to the practices":           ", wähle die maximale Entfernung zu den Praxen",
+	"Send /language followed by %s":                            "Schick /language gefolgt von %s",
+	"I speak %s with you from now on":                          "Ich spreche ab jetzt %s mit dir",
+	"I speak %s with you":                                      "Ich spreche %s mit dir",
+	"%q is not a known vaccine":                                "%q ist kein bekannter Impfstoff",
+	"%q is not a Berlin district, send /district to list them": "%q ist kein Berliner Bezirk, schick /district, um sie aufzulisten",
+	"Send /district followed by one of:\n%s":                   "Schick /district gefolgt von einem der Bezirke:\n%s",
+	"subscribed to the appointments in %s":                     "du bekommst die Termine in %s",
+	"subscribed to the appointments on %s":                     "du bekommst die Termine zu den Zeiten %s",
+	"your timezone is %s":                                      "deine Zeitzone ist %s",
+	"Send /days followed by how many days ahead you look for appointments, 0 for any date":                                                                                      "Schick /days gefolgt davon, wie viele Tage im Voraus du Termine suchst, 0 für jedes Datum",
+	"Send /quiet followed by hours like \"22:00-07:00\", or /quiet off":                                                                                                         "Schick /quiet gefolgt von Uhrzeiten wie „22:00-07:00“, oder /quiet off",
+	"Send /timezone followed by a timezone like \"Europe/Berlin\"":                                                                                                              "Schick /timezone gefolgt von einer Zeitzone wie „Europe/Berlin“",
+	"Send /digest followed by a duration of at least %s like \"90m\" or \"6h\", or /digest off":                                                                                 "Schick /digest gefolgt von einer Dauer von mindestens %s wie „90m“ oder „6h“, oder /digest off",
+	"Send /filter add, remove or set followed by a vaccine like \"pfizer\" or \"mrna\", set takes several vaccines separated by commas. Send /filter list to list your filters": "Schick /filter add, remove oder set gefolgt von einem Impfstoff wie „pfizer“ oder „mrna“, set nimmt mehrere durch Kommas getrennte Impfstoffe. Schick /filter list, um deine Filter aufzulisten",
+	"Send /slots followed by days and an optional time range, like \"mon-fri 17:00-24:00\", \"weekend\" or \"sat 10:00-14:00\"":                                                 "Schick /slots gefolgt von Tagen und optional Uhrzeiten, wie „mon-fri 17:00-24:00“, „weekend“ oder „sat 10:00-14:00“",
+	"Send /min followed by the minimum of free slots and an optional vaccine like \"5 pfizer\" or \"3 mrna\", 0 removes the minimum":                                            "Schick /min gefolgt von der Mindestanzahl freier Termine und optional einem Impfstoff wie „5 pfizer“ oder „3 mrna“, 0 entfernt das Minimum",
+
+	// the descriptions of the settings
+	"you look for every vaccine":               "du suchst jeden Impfstoff",
+	"you look for every dose":                  "du suchst jede Dosis",
+	"you look for %s":                          "du suchst %s",
+	"you look for appointments at any date":    "du suchst Termine an jedem Datum",
+	"you look for appointments within %d days": "du suchst Termine innerhalb von %d Tagen",
+	", on %s": ", zu den Zeiten %s",
+	" or ":    " oder ",
+	", skipping the ones whose time is unknown": ", ohne die mit unbekannter Uhrzeit",
+	"in %s":                                 "in %s",
+	"within %g km (no location shared yet)": "im Umkreis von %g km (noch kein Standort geteilt)",
+	"within %g km":                          "im Umkreis von %g km",
+	"you look for appointments anywhere in Berlin":          "du suchst Termine überall in Berlin",
+	"you look for appointments %s":                          "du suchst Termine %s",
+	"you receive the alerts of every source":                "du bekommst die Meldungen aller Quellen",
+	"you only receive the alerts of %s":                     "du bekommst nur die Meldungen von %s",
+	", except %s":                                           ", außer %s",
+	"you receive the alerts whatever their amount of slots": "du bekommst die Meldungen unabhängig von der Anzahl der Termine",
+	"you receive the alerts of at least %d slots":           "du bekommst die Meldungen mit mindestens %d Terminen",
+	"%d for %s": "%d für %s",
+	", skipping the ones whose amount is unknown when there is a minimum": ", ohne die mit unbekannter Anzahl, wenn es ein Minimum gibt",
+	"you receive the alerts instantly":                                    "du bekommst die Meldungen sofort",
+	"you receive the alerts in a digest every %s":                         "du bekommst die Meldungen als Zusammenfassung alle %s",
+	"you have no quiet hours":                                             "du hast keine Ruhezeiten",
+	"your quiet hours are %s (%s), %s":                                    "deine Ruhezeiten sind %s (%s), %s",
+	"the alerts are dropped":                                              "die Meldungen werden verworfen",
+	"the alerts are sent silently":                                        "die Meldungen werden lautlos gesendet",
+	"the alerts are sent when they end":                                   "die Meldungen werden danach gesendet",
+	"🌙 found at %s during your quiet hours":                               "🌙 um %s während deiner Ruhezeiten gefunden",
+
+	// the settings message
+	"Tap a setting to change it":  "Tippe auf eine Einstellung, um sie zu ändern",
+	"💉 Vaccines":                  "💉 Impfstoffe",
+	"🔢 Doses":                     "🔢 Dosen",
+	"📍 Where":                     "📍 Wo",
+	"🗓 When":                      "🗓 Wann",
+	"🕐 Times of the week":         "🕐 Wochenzeiten",
+	"👥 Minimum of slots":          "👥 Mindestanzahl",
+	"🌙 Quiet hours":               "🌙 Ruhezeiten",
+	"📋 Instant alerts or digests": "📋 Sofort oder gesammelt",
+	"🏥 Sources":                   "🏥 Quellen",
+	"🌐 Language":                  "🌐 Sprache",
+	"Tap the vaccines you look for, or Only to look for one of them alone. Every vaccine is sent when none is checked. You can also send /filter add, remove or list":                                                                         "Tippe auf die Impfstoffe, die du suchst, oder auf Nur, um nur einen davon zu suchen. Jeder Impfstoff wird gesendet, wenn keiner ausgewählt ist. Du kannst auch /filter add, remove oder list schicken",
+	"Tap the doses you look for, every dose is sent when none is checked":                                                                                                                                                                     "Tippe auf die Dosen, die du suchst, jede Dosis wird gesendet, wenn keine ausgewählt ist",
+	"Share your location with the keyboard button or send your postcode, then choose how far you can go. You can also look for districts with /district":                                                                                      "Teile deinen Standort mit dem Knopf der Tastatur oder schick deine Postleitzahl, und wähle dann, wie weit du fahren kannst. Du kannst mit /district auch nach Bezirken suchen",
+	"Choose how many days ahead you look for appointments, or send /days followed by a number":                                                                                                                                                "Wähle, wie viele Tage im Voraus du Termine suchst, oder schick /days gefolgt von einer Zahl",
+	"Tap when you can go to an appointment, or send /slots followed by days and hours like \"mon-fri 17:00-24:00\"":                                                                                                                           "Tippe an, wann du zu einem Termin gehen kannst, oder schick /slots gefolgt von Tagen und Uhrzeiten wie „mon-fri 17:00-24:00“",
+	"Choose how many free slots an alert needs before you receive it, or send /min followed by a number and an optional vaccine like \"5 pfizer\". Some sources can't count their slots, choose whether you receive their alerts":             "Wähle, wie viele freie Termine eine Meldung haben muss, bevor du sie bekommst, oder schick /min gefolgt von einer Zahl und optional einem Impfstoff wie „5 pfizer“. Manche Quellen können ihre Termine nicht zählen, wähle, ob du ihre Meldungen bekommst",
+	"Choose when you do not want to be disturbed and what happens to the alerts then, or send /quiet followed by hours like \"22:00-07:00\". The hours are in your timezone, Europe/Berlin unless you send /timezone followed by another one": "Wähle, wann du nicht gestört werden willst und was dann mit den Meldungen passiert, oder schick /quiet gefolgt von Uhrzeiten wie „22:00-07:00“. Die Uhrzeiten sind in deiner Zeitzone, Europe/Berlin, außer du schickst /timezone gefolgt von einer anderen",
+	"Choose to receive the alerts instantly or in a digest, or send /digest followed by a duration like \"90m\"":                                                                                                                              "Wähle, ob du die Meldungen sofort oder als Zusammenfassung bekommst, oder schick /digest gefolgt von einer Dauer wie „90m“",
+	"Tap a source to mute or unmute it. Star sources to only receive their alerts, every source is sent when none is starred":                                                                                                                 "Tippe auf eine Quelle, um sie stumm oder wieder laut zu schalten. Markiere Quellen mit einem Stern, um nur ihre Meldungen zu bekommen, jede Quelle wird gesendet, wenn keine markiert ist",
+	"Choose the language I speak with you, or send /language followed by its code":                                                                                                                                                            "Wähle die Sprache, die ich mit dir spreche, oder schick /language gefolgt von ihrem Code",
+	"Only":                                "Nur",
+	"Every vaccine":                       "Jeder Impfstoff",
+	"First doses":                         "Erstimpfungen",
+	"Second doses":                        "Zweitimpfungen",
+	"Boosters":                            "Auffrischimpfungen",
+	"Every dose":                          "Jede Dosis",
+	"Within %s km":                        "Im Umkreis von %s km",
+	"Anywhere in Berlin":                  "Überall in Berlin",
+	"Send my postcode":                    "Meine Postleitzahl schicken",
+	"Add a district":                      "Einen Bezirk hinzufügen",
+	"Within 3 days":                       "Innerhalb von 3 Tagen",
+	"Within 1 week":                       "Innerhalb von 1 Woche",
+	"Within 2 weeks":                      "Innerhalb von 2 Wochen",
+	"Within 6 weeks":                      "Innerhalb von 6 Wochen",
+	"Any date":                            "Jedes Datum",
+	"Other number of days":                "Andere Anzahl von Tagen",
+	"Weekday evenings (after 17:00)":      "Werktags abends (ab 17:00)",
+	"Weekends":                            "Wochenenden",
+	"Any time":                            "Jederzeit",
+	"Other times":                         "Andere Zeiten",
+	"Skip appointments with unknown time": "Termine mit unbekannter Uhrzeit überspringen",
+	"Any amount of slots":                 "Beliebig viele Termine",
+	"At least 3 slots":                    "Mindestens 3 Termine",
+	"At least 10 slots":                   "Mindestens 10 Termine",
+	"Skip alerts with unknown amount":     "Meldungen mit unbekannter Anzahl überspringen",
+	"Other minimum, or one per vaccine":   "Anderes Minimum, oder eins pro Impfstoff",
+	"Quiet from 22:00 to 07:00":           "Ruhe von 22:00 bis 07:00",
+	"Quiet from 23:00 to 09:00":           "Ruhe von 23:00 bis 09:00",
+	"No quiet hours":                      "Keine Ruhezeiten",
+	"Other hours":                         "Andere Uhrzeiten",
+	"Drop the alerts during quiet hours":  "Meldungen während der Ruhezeiten verwerfen",
+	"Send the alerts silently during quiet hours": "Meldungen während der Ruhezeiten lautlos senden",
+	"Send the alerts after quiet hours":           "Meldungen nach den Ruhezeiten senden",
+	"Change my timezone":                          "Meine Zeitzone ändern",
+	"Instant alerts":                              "Sofortige Meldungen",
+	"Digest every 30 minutes":                     "Zusammenfassung alle 30 Minuten",
+	"Digest every 2 hours":                        "Zusammenfassung alle 2 Stunden",
+	"Digest every day":                            "Zusammenfassung jeden Tag",
+	"Other interval":                              "Anderer Abstand",
+	"Every source":                                "Jede Quelle",
+
+	// the dialogs
+	"Send /cancel to stop":                                     "Schick /cancel zum Abbrechen",
+	"there is no question to cancel":                           "es gibt keine Frage zum Abbrechen",
+	"cancelled, nothing was changed":                           "abgebrochen, nichts wurde geändert",
+	"⌛ I stopped waiting for your answer, nothing was changed": "⌛ Ich warte nicht mehr auf deine Antwort, nichts wurde geändert",
+	"off":                         "aus",
+	"all":                         "alle",
+	"What is your postcode?":      "Wie ist deine Postleitzahl?",
+	"%q is not a Berlin postcode": "%q ist keine Berliner Postleitzahl",
+	"Which district do you look for? One of:\n%s":                                     "Welchen Bezirk suchst du? Einen von:\n%s",
+	"%q is not a Berlin district":                                                     "%q ist kein Berliner Bezirk",
+	"How many days ahead do you look for appointments? Send a number, 0 for any date": "Wie viele Tage im Voraus suchst du Termine? Schick eine Zahl, 0 für jedes Datum",
+	"%q is not a number of days":                                                      "%q ist keine Anzahl von Tagen",
+	"When can you go to an appointment? Send days and an optional time range, like \"mon-fri 17:00-24:00\", \"weekend\" or \"sat 10:00-14:00\"": "Wann kannst du zu einem Termin gehen? Schick Tage und optional Uhrzeiten, wie „mon-fri 17:00-24:00“, „weekend“ oder „sat 10:00-14:00“",
+	"%q are not days and hours I understand":                                            "%q sind keine Tage und Uhrzeiten, die ich verstehe",
+	"When do you not want to be disturbed? Send hours like \"22:00-07:00\", or \"off\"": "Wann willst du nicht gestört werden? Schick Uhrzeiten wie „22:00-07:00“, oder „aus“",
+	"%q are not hours I understand":                                                     "%q sind keine Uhrzeiten, die ich verstehe",
+	"What is your timezone? Send its name like \"Europe/Berlin\"":                       "Was ist deine Zeitzone? Schick ihren Namen wie „Europe/Berlin“",
+	"%q is not a timezone I know":                                                       "%q ist keine Zeitzone, die ich kenne",
+	"How often do you want a digest? Send a duration of at least %s like \"90m\" or \"6h\", or \"off\" for instant alerts": "Wie oft willst du eine Zusammenfassung? Schick eine Dauer von mindestens %s wie „90m“ oder „6h“, oder „aus“ für sofortige Meldungen",
+	"%q is not a duration of at least %s": "%q ist keine Dauer von mindestens %s",
+	"How many free slots does an alert need before you receive it? Send a number, 0 for any amount": "Wie viele freie Termine muss eine Meldung haben, bevor du sie bekommst? Schick eine Zahl, 0 für beliebig viele",
+	"%q is not a number of slots": "%q ist keine Anzahl von Terminen",
+	"For which vaccine? Send a vaccine like \"pfizer\" or \"mrna\", or \"all\" for every vaccine": "Für welchen Impfstoff? Schick einen Impfstoff wie „pfizer“ oder „mrna“, oder „alle“ für jeden Impfstoff",
+	"%q is not a vaccine I know": "%q ist kein Impfstoff, den ich kenne",
+
+	// the errors of the settings and of the commands
+	"Please send /start first":               "Bitte schick zuerst /start",
+	"Something went wrong, please try again": "Etwas ist schiefgelaufen, bitte versuch es nochmal",
+
+	// the alerts
+	" and %d more":  " und %d weitere",
+	"first dose":    "Erstimpfung",
+	"second dose":   "Zweitimpfung",
+	"booster dose":  "Auffrischimpfung",
+	"first doses":   "Erstimpfungen",
+	"second doses":  "Zweitimpfungen",
+	"booster doses": "Auffrischimpfungen",
+
+	// the names of the vaccines
+	"BioNTech/Pfizer adapted booster":    "BioNTech/Pfizer angepasster Booster",
+	"Moderna adapted booster":            "Moderna angepasster Booster",
+	"mRNA vaccine (BioNTech or Moderna)": "mRNA-Impfstoff (BioNTech oder Moderna)",
+	"every mRNA vaccine":                 "jeder mRNA-Impfstoff",
+}
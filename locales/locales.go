@@ -0,0 +1,140 @@
+// Package locales translates the messages of the bot. The messages are written
+// in English in the code and are the keys of the catalogues of the other
+// languages.
+package locales
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Language is the code of a language the bot speaks, like "de"
+type Language string
+
+const (
+	// English is the language of the messages in the code, and the language of
+	// the chats whose language is unknown
+	English Language = "en"
+	// German is the language most of the users in Berlin prefer
+	German Language = "de"
+)
+
+// ErrLanguageNotFound is return when the language is not one the bot speaks
+var ErrLanguageNotFound = errors.New("language not found")
+
+// info describes a language the bot speaks
+type info struct {
+	// name is the name of the language in itself
+	name string
+	// aliases are the names the users may give to the language
+	aliases []string
+	// catalogue maps the English messages to their translation, the messages
+	// missing from it are sent in English
+	catalogue map[string]string
+	// weekdays are the names of the days, starting with sunday like
+	// time.Weekday
+	weekdays [7]string
+	// shortWeekdays are the abbreviated names of the days
+	shortWeekdays [7]string
+}
+
+var languages = map[Language]info{
+	English: {
+		name:    "English",
+		aliases: []string{"english", "englisch"},
+	},
+	German: {
+		name:          "Deutsch",
+		aliases:       []string{"deutsch", "german"},
+		catalogue:     german,
+		weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+}
+
+// Languages returns the languages the bot speaks
+func Languages() []Language {
+	return []Language{English, German}
+}
+
+// Parse returns the language given by its code, like "de" or "de-AT", or by its
+// name in English or in itself
+func Parse(text string) (Language, error) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if i := strings.IndexAny(lower, "-_"); i >= 0 {
+		lower = lower[:i]
+	}
+	for _, language := range Languages() {
+		if lower == string(language) {
+			return language, nil
+		}
+		for _, alias := range languages[language].aliases {
+			if lower == alias {
+				return language, nil
+			}
+		}
+	}
+	return "", ErrLanguageNotFound
+}
+
+// FromCode returns the language of the IETF language tag telegram gives for
+// its users, English when the bot does not speak it
+func FromCode(code string) Language {
+	language, err := Parse(code)
+	if err != nil {
+		return English
+	}
+	return language
+}
+
+// Name returns the name of the language in itself
+func (l Language) Name() string {
+	if info, ok := languages[l]; ok {
+		return info.name
+	}
+	return string(l)
+}
+
+// Translate returns the message in the language, or the message itself when it
+// has no translation
+func (l Language) Translate(message string) string {
+	if translated, ok := languages[l].catalogue[message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf translates the format and formats it with the arguments, the
+// translation keeps the verbs of the format
+func (l Language) Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(l.Translate(format), args...)
+}
+
+// FormatTime formats the time like time.Format, with the names of the days in
+// the language
+func (l Language) FormatTime(t time.Time, layout string) string {
+	info, ok := languages[l]
+	if !ok || info.weekdays[0] == "" {
+		return t.Format(layout)
+	}
+
+	var formatted strings.Builder
+	for layout != "" {
+		i := strings.Index(layout, "Mon")
+		if i < 0 {
+			formatted.WriteString(t.Format(layout))
+			break
+		}
+		formatted.WriteString(t.Format(layout[:i]))
+		if strings.HasPrefix(layout[i:], "Monday") {
+			formatted.WriteString(info.weekdays[t.Weekday()])
+			layout = layout[i+len("Monday"):]
+		} else {
+			formatted.WriteString(info.shortWeekdays[t.Weekday()])
+			layout = layout[i+len("Mon"):]
+		}
+	}
+	return formatted.String()
+}
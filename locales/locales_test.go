@@ -0,0 +1,35 @@
+package locales
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	// the 16th of October 2026 is a Friday
+	friday := time.Date(2026, 10, 16, 17, 30, 0, 0, time.UTC)
+	sunday := time.Date(2026, 10, 18, 9, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		language Language
+		time     time.Time
+		layout   string
+		want     string
+	}{
+		{name: "english slot", language: English, time: friday, layout: "Mon 02.01. 15:04", want: "Fri 16.10. 17:30"},
+		{name: "english day", language: English, time: sunday, layout: "Monday 02.01.", want: "Sunday 18.10."},
+		{name: "german slot", language: German, time: friday, layout: "Mon 02.01. 15:04", want: "Fr 16.10. 17:30"},
+		{name: "german day", language: German, time: sunday, layout: "Monday 02.01.", want: "Sonntag 18.10."},
+		{name: "german short and long days", language: German, time: sunday, layout: "Mon, Monday", want: "So, Sonntag"},
+		{name: "german without day", language: German, time: friday, layout: "02.01.2006 15:04", want: "16.10.2026 17:30"},
+		{name: "unknown language", language: Language("fr"), time: friday, layout: "Mon 02.01. 15:04", want: "Fri 16.10. 17:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.language.FormatTime(tt.time, tt.layout); got != tt.want {
+				t.Errorf("FormatTime(%q) = %q, want %q", tt.layout, got, tt.want)
+			}
+		})
+	}
+}
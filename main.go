@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"sync"
-	"time"
+	"syscall"
 
+	"github.com/eleboucher/berlin-vaccine-alert/dedup"
+	"github.com/eleboucher/berlin-vaccine-alert/models/announcement"
 	"github.com/eleboucher/berlin-vaccine-alert/models/chat"
+	"github.com/eleboucher/berlin-vaccine-alert/models/delivery"
+	"github.com/eleboucher/berlin-vaccine-alert/models/dialog"
+	"github.com/eleboucher/berlin-vaccine-alert/models/digest"
+	"github.com/eleboucher/berlin-vaccine-alert/scheduler"
 	"github.com/eleboucher/berlin-vaccine-alert/sources"
 	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
 
@@ -19,60 +27,61 @@ import (
 	"github.com/spf13/viper"
 )
 
-// Fetcher is the type to allow fetching information for an appointment
-type Fetcher interface {
-	Name() string
-	Fetch() ([]*vaccines.Result, error)
-	ShouldSendResult(result []*vaccines.Result) bool
-	ResultSentNow(result []*vaccines.Result)
-}
-
 var rootCmd = &cobra.Command{
 	Use: "berlin-vaccine-alert <command>",
 }
 
-func fetchAllAppointment(fetchers []Fetcher, bot *Telegram) {
-	done := make(chan bool)
-	errChan := make(chan error)
-
-	for _, fetcher := range fetchers {
-		fetcher := fetcher
-		go func() {
-			log.Infof("%s: Starting fetch", fetcher.Name())
-			res, err := fetcher.Fetch()
-			if err != nil {
-				errChan <- err
-				return
+// sendAppointments returns the scheduler handler sending the results of a batch
+// that are worth announcing on telegram
+func sendAppointments(bot *Telegram, policy *dedup.Policy) scheduler.Handler {
+	return func(ctx context.Context, outcomes []*scheduler.Outcome) {
+		// the results of a batch are sent together so every chat gets one message
+		var results []*vaccines.Result
+		for _, outcome := range outcomes {
+			fetcher := outcome.Source
+			if outcome.Err != nil {
+				// the scheduler already reported the error along with the health of the source
+				continue
 			}
+			res := outcome.Results
 			log.Infof("%s: Received %d result", fetcher.Name(), len(res))
-			if len(res) > 0 && fetcher.ShouldSendResult(res) {
-				fetcher.ResultSentNow(res)
-				for _, r := range res {
-
-					err = bot.SendMessageToAllUser(r)
-					if err != nil {
-						errChan <- err
-						return
-					}
-				}
-				log.Infof("%s: messages sent on telegram", fetcher.Name())
-			}
-			done <- true
-		}()
+			results = append(results, policy.Filter(fetcher.Name(), fetcher.Settings.Dedup, res)...)
+		}
+		if len(results) == 0 {
+			return
+		}
+		err := bot.SendResultsToAllUser(results)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		log.Infof("%d results sent on telegram", len(results))
 	}
+}
 
-	timeout := time.After(5 * time.Second)
-	for {
-		select {
-		case <-done:
-			continue
-		case <-timeout:
-			return
-		case err := <-errChan:
-			log.Errorf("%v\n", err)
+// newPolicy returns the dedup policy restored from the announcements saved in the database
+func newPolicy(model *announcement.Model) (*dedup.Policy, error) {
+	announcements, err := model.List()
+	if err != nil {
+		return nil, err
+	}
+	policy := dedup.New(model)
+	for _, a := range announcements {
+		policy.Restore(
+			dedup.Key{Source: a.Source, Vaccine: a.Vaccine, Dose: a.Dose, Detail: a.Detail},
+			dedup.Announcement{Fingerprint: a.Fingerprint, At: a.AnnouncedAt},
+		)
+	}
+	return policy, nil
+}
 
-		}
+// adminChats returns the set of chats allowed to use the admin commands
+func adminChats(ids []int) map[int64]bool {
+	admins := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		admins[int64(id)] = true
 	}
+	return admins
 }
 
 func init() {
@@ -112,28 +121,55 @@ func main() {
 		return
 	}
 	chatModel := chat.NewModel(db)
-	telegram := NewBot(bot, chatModel)
-
-	var s = []Fetcher{
-		&sources.PuntoMedico{},
-		&sources.MedicoLeopoldPlatz{},
-		&sources.ArkonoPlatz{},
-		&sources.ArkonoPlatzJJ{},
-		&sources.ArkonoPlatzPfizer{},
-		&sources.Helios{},
+	telegram := NewBot(bot, chatModel, delivery.NewModel(db), digest.NewModel(db), dialog.NewModel(db), adminChats(viper.GetIntSlice("ADMIN_CHAT_IDS")))
+
+	var entries []map[string]interface{}
+	err = viper.UnmarshalKey("sources", &entries)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	s, err := sources.Load(entries)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.Infof("loaded %d sources", len(s))
+	for _, source := range s {
+		telegram.sources = append(telegram.sources, source.Name())
+	}
+
+	var schedulerOptions scheduler.Options
+	err = viper.UnmarshalKey("scheduler", &schedulerOptions)
+	if err != nil {
+		log.Error(err)
+		return
 	}
 
 	var runCMD = &cobra.Command{
 		Use:   "run",
 		Short: "run the telegram bot",
 		Run: func(cmd *cobra.Command, args []string) {
+			// cancel every running fetch and stop the bot on shutdown
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			policy, err := newPolicy(announcement.NewModel(db))
+			if err != nil {
+				log.Error(err)
+				return
+			}
+
+			sched := scheduler.New(s, schedulerOptions, sendAppointments(telegram, policy))
+			telegram.health = sched
+
 			var wg sync.WaitGroup
 
-			wg.Add(2)
+			wg.Add(5)
 
 			go func() {
 				defer wg.Done()
-				err := telegram.HandleNewUsers()
+				err := telegram.HandleNewUsers(ctx)
 				if err != nil {
 					log.Error(err)
 					return
@@ -142,12 +178,26 @@ func main() {
 
 			go func() {
 				defer wg.Done()
-				for range time.Tick(30 * time.Second) {
-					go fetchAllAppointment(s, telegram)
-				}
+				sched.Run(ctx)
+			}()
+
+			go func() {
+				defer wg.Done()
+				telegram.DeliverQueued(ctx)
+			}()
+
+			go func() {
+				defer wg.Done()
+				telegram.SendDigests(ctx)
+			}()
+
+			go func() {
+				defer wg.Done()
+				telegram.ExpireDialogs(ctx)
 			}()
 
 			wg.Wait()
+			log.Info("shutting down")
 		},
 	}
 
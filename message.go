@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+const (
+	// maxListedSlots is the maximum of slots listed in a message
+	maxListedSlots = 5
+	// maxMessageLength is the maximum length of a telegram message
+	maxMessageLength = 4096
+	// resultSeparator separates the results grouped in a message
+	resultSeparator = "\n\n〰〰〰\n\n"
+)
+
+const tResult = `{{if .Amount}}{{.Amount}} appointments{{else}}Appointments{{end}} for {{vaccine .Vaccine}}{{with .Dose}} ({{dose .}}){{end}} available
+{{if .Practice}}🏥 {{.Practice}}{{if .District}} ({{.District}}){{end}}
+{{else if .District}}🏥 {{.District}}
+{{end}}{{if .Address}}📍 {{.Address}}
+{{end}}{{with slots .}}🗓 {{.}}
+{{end}}{{if .TimeUnknown}}🕐 time unknown
+{{end}}{{if .Detail}}ℹ️ {{.Detail}}
+{{end}}{{if .Phone}}📞 {{.Phone}}
+{{end}}{{if .BookingURL}}👉 {{.BookingURL}}
+{{end}}`
+
+const tResultGerman = `{{if .Amount}}{{.Amount}} Termine{{else}}Termine{{end}} für {{vaccine .Vaccine}}{{with .Dose}} ({{dose .}}){{end}} verfügbar
+{{if .Practice}}🏥 {{.Practice}}{{if .District}} ({{.District}}){{end}}
+{{else if .District}}🏥 {{.District}}
+{{end}}{{if .Address}}📍 {{.Address}}
+{{end}}{{with slots .}}🗓 {{.}}
+{{end}}{{if .TimeUnknown}}🕐 Uhrzeit unbekannt
+{{end}}{{if .Detail}}ℹ️ {{.Detail}}
+{{end}}{{if .Phone}}📞 {{.Phone}}
+{{end}}{{if .BookingURL}}👉 {{.BookingURL}}
+{{end}}`
+
+const tDigest = `📋 {{len .}} appointment offers since your last digest
+{{range .}}
+🏥 {{practice .}}: {{vaccine .Vaccine}}{{with .Dose}} ({{dose .}}){{end}}{{if .Amount}}, {{.Amount}} appointments{{end}}{{with earliest .}}, from {{.}}{{end}}
+{{if .BookingURL}}👉 {{.BookingURL}}
+{{else if .Phone}}📞 {{.Phone}}
+{{end}}{{end}}`
+
+const tDigestGerman = `📋 {{len .}} Terminangebote seit deiner letzten Zusammenfassung
+{{range .}}
+🏥 {{practice .}}: {{vaccine .Vaccine}}{{with .Dose}} ({{dose .}}){{end}}{{if .Amount}}, {{.Amount}} Termine{{end}}{{with earliest .}}, ab {{.}}{{end}}
+{{if .BookingURL}}👉 {{.BookingURL}}
+{{else if .Phone}}📞 {{.Phone}}
+{{end}}{{end}}`
+
+// berlin is the timezone the slots are shown in
+var berlin, _ = time.LoadLocation("Europe/Berlin")
+
+var resultTemplates = parseTemplates("result", map[locales.Language]string{
+	locales.English: tResult,
+	locales.German:  tResultGerman,
+})
+
+var digestTemplates = parseTemplates("digest", map[locales.Language]string{
+	locales.English: tDigest,
+	locales.German:  tDigestGerman,
+})
+
+// parseTemplates parses the template of every language, with the functions
+// formatting in that language
+func parseTemplates(name string, texts map[locales.Language]string) map[locales.Language]*template.Template {
+	templates := make(map[locales.Language]*template.Template, len(texts))
+	for language, text := range texts {
+		language := language
+		funcs := template.FuncMap{
+			"vaccine": func(vaccine vaccines.Vaccine) string {
+				return language.Translate(vaccine.DisplayName())
+			},
+			"dose": func(dose vaccines.Dose) string {
+				return formatDose(language, dose)
+			},
+			"slots": func(result *vaccines.Result) string {
+				return formatSlots(language, result)
+			},
+			"earliest": func(result *vaccines.Result) string {
+				return formatEarliest(language, result)
+			},
+			"practice": practiceName,
+		}
+		templates[language] = template.Must(template.New(name).Funcs(funcs).Parse(text))
+	}
+	return templates
+}
+
+// executeTemplate renders the template of the language, or the English one
+// when the language has none
+func executeTemplate(templates map[locales.Language]*template.Template, language locales.Language, data interface{}) (string, error) {
+	tmpl, ok := templates[language]
+	if !ok {
+		tmpl = templates[locales.English]
+	}
+	var tpl bytes.Buffer
+	err := tmpl.Execute(&tpl, data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(tpl.String()), nil
+}
+
+// digestKey identifies the offers of a digest, the last result of each is listed
+type digestKey struct {
+	source  string
+	vaccine vaccines.Vaccine
+	dose    vaccines.Dose
+}
+
+// formatDigest renders the results accumulated for a digest to one message in
+// the language, listing the last result of every source, vaccine and dose
+func formatDigest(language locales.Language, results []*vaccines.Result) (string, error) {
+	index := make(map[digestKey]int)
+	var offers []*vaccines.Result
+	for _, result := range results {
+		key := digestKey{source: result.Source, vaccine: result.Vaccine, dose: result.Dose}
+		if i, ok := index[key]; ok {
+			offers[i] = result
+			continue
+		}
+		index[key] = len(offers)
+		offers = append(offers, result)
+	}
+
+	return executeTemplate(digestTemplates, language, offers)
+}
+
+// practiceName returns the name of the practice of the result, or of its
+// source when the practice is unknown
+func practiceName(result *vaccines.Result) string {
+	name := result.Practice
+	if name == "" {
+		name = result.Source
+	}
+	if result.District != "" {
+		name += " (" + result.District + ")"
+	}
+	return name
+}
+
+// formatEarliest formats the earliest slot of the result
+func formatEarliest(language locales.Language, result *vaccines.Result) string {
+	if result.EarliestSlot.IsZero() {
+		return ""
+	}
+	if result.DateOnly {
+		return language.FormatTime(result.EarliestSlot.In(berlin), "Mon 02.01.")
+	}
+	return language.FormatTime(result.EarliestSlot.In(berlin), "Mon 02.01. 15:04")
+}
+
+// formatDose names the dose of an appointment
+func formatDose(language locales.Language, dose vaccines.Dose) string {
+	return language.Translate(string(dose) + " dose")
+}
+
+// formatResults renders the results in the language to as few messages as the
+// length limit of telegram allows, a message never splits a result
+func formatResults(language locales.Language, results []*vaccines.Result) ([]string, error) {
+	var messages []string
+	var current string
+	for _, result := range results {
+		formatted, err := formatResult(language, result)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case current == "":
+			current = formatted
+		case utf8.RuneCountInString(current+resultSeparator+formatted) > maxMessageLength:
+			messages = append(messages, current)
+			current = formatted
+		default:
+			current += resultSeparator + formatted
+		}
+	}
+	if current != "" {
+		messages = append(messages, current)
+	}
+	return messages, nil
+}
+
+// formatResult renders a result to the message sent on telegram
+func formatResult(language locales.Language, result *vaccines.Result) (string, error) {
+	return executeTemplate(resultTemplates, language, result)
+}
+
+// formatSlots lists the first slots of the result, and how many are left
+func formatSlots(language locales.Language, result *vaccines.Result) string {
+	if len(result.Slots) == 0 {
+		return ""
+	}
+	layout := "Mon 02.01. 15:04"
+	if result.DateOnly {
+		layout = "Mon 02.01."
+	}
+
+	listed := result.Slots
+	if len(listed) > maxListedSlots {
+		listed = listed[:maxListedSlots]
+	}
+	formatted := make([]string, 0, len(listed))
+	for _, slot := range listed {
+		formatted = append(formatted, language.FormatTime(slot.In(berlin), layout))
+	}
+	ret := strings.Join(formatted, ", ")
+	if len(result.Slots) > len(listed) {
+		ret += language.Sprintf(" and %d more", len(result.Slots)-len(listed))
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+func TestFormatResults(t *testing.T) {
+	// results returns n results whose detail has the length, each one naming
+	// its practice so the messages can be checked to keep them whole
+	results := func(n int, detail int) []*vaccines.Result {
+		ret := make([]*vaccines.Result, 0, n)
+		for i := 0; i < n; i++ {
+			ret = append(ret, &vaccines.Result{
+				Vaccine:  vaccines.Pfizer,
+				Practice: fmt.Sprintf("practice %d", i),
+				Detail:   strings.Repeat("ä", detail),
+			})
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name         string
+		results      []*vaccines.Result
+		wantMessages int
+	}{
+		{name: "no result", results: nil, wantMessages: 0},
+		{name: "one result", results: results(1, 10), wantMessages: 1},
+		{name: "short results in one message", results: results(10, 100), wantMessages: 1},
+		{name: "split at the limit", results: results(5, 1500), wantMessages: 3},
+		{name: "result longer than the limit alone", results: results(2, maxMessageLength), wantMessages: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := formatResults(locales.English, tt.results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(messages) != tt.wantMessages {
+				t.Fatalf("formatResults() returned %d messages, want %d", len(messages), tt.wantMessages)
+			}
+
+			joined := strings.Join(messages, resultSeparator)
+			for i, result := range tt.results {
+				formatted, err := formatResult(locales.English, result)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(joined, formatted) {
+					t.Errorf("result %d is missing or split", i)
+				}
+			}
+			for i, message := range messages {
+				// a message is only longer than the limit when it holds a
+				// single result that is
+				if utf8.RuneCountInString(message) > maxMessageLength && strings.Contains(message, resultSeparator) {
+					t.Errorf("message %d has %d characters", i, utf8.RuneCountInString(message))
+				}
+			}
+		})
+	}
+}
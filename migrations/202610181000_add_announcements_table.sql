@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS announcements (
+    source TEXT NOT NULL,
+    vaccine TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    announced_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (source, vaccine)
+);
+
+
+-- +migrate Down
+DROP TABLE announcements;
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS doses TEXT;
+
+
+-- +migrate Down
+ALTER TABLE chats DROP COLUMN doses;
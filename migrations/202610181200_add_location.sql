@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS max_distance DOUBLE PRECISION;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS districts TEXT;
+
+
+-- +migrate Down
+ALTER TABLE chats DROP COLUMN latitude;
+ALTER TABLE chats DROP COLUMN longitude;
+ALTER TABLE chats DROP COLUMN max_distance;
+ALTER TABLE chats DROP COLUMN districts;
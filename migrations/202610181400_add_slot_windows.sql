@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS slot_windows TEXT;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS skip_time_unknown BOOLEAN NOT NULL DEFAULT false;
+
+
+-- +migrate Down
+ALTER TABLE chats DROP COLUMN slot_windows;
+ALTER TABLE chats DROP COLUMN skip_time_unknown;
@@ -0,0 +1,21 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS quiet_start INTEGER;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS quiet_end INTEGER;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS quiet_mode TEXT NOT NULL DEFAULT 'silent';
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'Europe/Berlin';
+
+CREATE TABLE IF NOT EXISTS deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id INTEGER NOT NULL REFERENCES chats (id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    deliver_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS deliveries_deliver_at_idx ON deliveries (deliver_at);
+
+
+-- +migrate Down
+DROP TABLE deliveries;
+ALTER TABLE chats DROP COLUMN quiet_start;
+ALTER TABLE chats DROP COLUMN quiet_end;
+ALTER TABLE chats DROP COLUMN quiet_mode;
+ALTER TABLE chats DROP COLUMN timezone;
@@ -0,0 +1,17 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS digest_interval INTEGER;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS digest_sent_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS digest_entries (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id INTEGER NOT NULL REFERENCES chats (id) ON DELETE CASCADE,
+    result JSONB NOT NULL,
+    found_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS digest_entries_chat_id_idx ON digest_entries (chat_id);
+
+
+-- +migrate Down
+DROP TABLE digest_entries;
+ALTER TABLE chats DROP COLUMN digest_interval;
+ALTER TABLE chats DROP COLUMN digest_sent_at;
@@ -0,0 +1,10 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS min_amount INTEGER;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS min_amounts JSONB;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS skip_uncounted BOOLEAN NOT NULL DEFAULT false;
+
+
+-- +migrate Down
+ALTER TABLE chats DROP COLUMN min_amount;
+ALTER TABLE chats DROP COLUMN min_amounts;
+ALTER TABLE chats DROP COLUMN skip_uncounted;
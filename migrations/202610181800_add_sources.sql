@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS sources TEXT;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS muted_sources TEXT;
+
+
+-- +migrate Down
+ALTER TABLE chats DROP COLUMN sources;
+ALTER TABLE chats DROP COLUMN muted_sources;
@@ -0,0 +1,24 @@
+-- +migrate Up
+ALTER TABLE chats ALTER COLUMN filters TYPE TEXT[] USING string_to_array(NULLIF(filters, ''), ',');
+ALTER TABLE chats ALTER COLUMN doses TYPE TEXT[] USING string_to_array(NULLIF(doses, ''), ',');
+ALTER TABLE chats ALTER COLUMN districts TYPE TEXT[] USING string_to_array(NULLIF(districts, ''), ',');
+ALTER TABLE chats ALTER COLUMN sources TYPE TEXT[] USING string_to_array(NULLIF(sources, ''), ',');
+ALTER TABLE chats ALTER COLUMN muted_sources TYPE TEXT[] USING string_to_array(NULLIF(muted_sources, ''), ',');
+ALTER TABLE chats ALTER COLUMN slot_windows TYPE TEXT[] USING string_to_array(NULLIF(slot_windows, ''), ';');
+CREATE INDEX IF NOT EXISTS chats_filters_idx ON chats USING GIN (filters);
+CREATE INDEX IF NOT EXISTS chats_doses_idx ON chats USING GIN (doses);
+CREATE INDEX IF NOT EXISTS chats_districts_idx ON chats USING GIN (districts);
+CREATE INDEX IF NOT EXISTS chats_sources_idx ON chats USING GIN (sources);
+
+
+-- +migrate Down
+DROP INDEX IF EXISTS chats_filters_idx;
+DROP INDEX IF EXISTS chats_doses_idx;
+DROP INDEX IF EXISTS chats_districts_idx;
+DROP INDEX IF EXISTS chats_sources_idx;
+ALTER TABLE chats ALTER COLUMN filters TYPE TEXT USING array_to_string(filters, ',');
+ALTER TABLE chats ALTER COLUMN doses TYPE TEXT USING array_to_string(doses, ',');
+ALTER TABLE chats ALTER COLUMN districts TYPE TEXT USING array_to_string(districts, ',');
+ALTER TABLE chats ALTER COLUMN sources TYPE TEXT USING array_to_string(sources, ',');
+ALTER TABLE chats ALTER COLUMN muted_sources TYPE TEXT USING array_to_string(muted_sources, ',');
+ALTER TABLE chats ALTER COLUMN slot_windows TYPE TEXT USING array_to_string(slot_windows, ';');
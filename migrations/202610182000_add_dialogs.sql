@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS dialogs (
+    chat_id INTEGER PRIMARY KEY REFERENCES chats (id) ON DELETE CASCADE,
+    step TEXT NOT NULL,
+    data JSONB,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS dialogs_expires_at_idx ON dialogs (expires_at);
+
+
+-- +migrate Down
+DROP TABLE dialogs;
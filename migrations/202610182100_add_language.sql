@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS language TEXT;
+
+
+-- +migrate Down
+ALTER TABLE chats DROP COLUMN language;
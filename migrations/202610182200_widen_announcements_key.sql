@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE announcements ADD COLUMN IF NOT EXISTS dose TEXT NOT NULL DEFAULT '';
+ALTER TABLE announcements ADD COLUMN IF NOT EXISTS detail TEXT NOT NULL DEFAULT '';
+ALTER TABLE announcements DROP CONSTRAINT announcements_pkey;
+ALTER TABLE announcements ADD PRIMARY KEY (source, vaccine, dose, detail);
+
+
+-- +migrate Down
+DELETE FROM announcements WHERE dose <> '' OR detail <> '';
+ALTER TABLE announcements DROP CONSTRAINT announcements_pkey;
+ALTER TABLE announcements ADD PRIMARY KEY (source, vaccine);
+ALTER TABLE announcements DROP COLUMN dose;
+ALTER TABLE announcements DROP COLUMN detail;
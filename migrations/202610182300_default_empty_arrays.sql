@@ -0,0 +1,28 @@
+-- +migrate Up
+UPDATE chats SET filters = '{}' WHERE filters IS NULL;
+UPDATE chats SET doses = '{}' WHERE doses IS NULL;
+UPDATE chats SET districts = '{}' WHERE districts IS NULL;
+UPDATE chats SET sources = '{}' WHERE sources IS NULL;
+UPDATE chats SET muted_sources = '{}' WHERE muted_sources IS NULL;
+UPDATE chats SET slot_windows = '{}' WHERE slot_windows IS NULL;
+ALTER TABLE chats ALTER COLUMN filters SET DEFAULT '{}', ALTER COLUMN filters SET NOT NULL;
+ALTER TABLE chats ALTER COLUMN doses SET DEFAULT '{}', ALTER COLUMN doses SET NOT NULL;
+ALTER TABLE chats ALTER COLUMN districts SET DEFAULT '{}', ALTER COLUMN districts SET NOT NULL;
+ALTER TABLE chats ALTER COLUMN sources SET DEFAULT '{}', ALTER COLUMN sources SET NOT NULL;
+ALTER TABLE chats ALTER COLUMN muted_sources SET DEFAULT '{}', ALTER COLUMN muted_sources SET NOT NULL;
+ALTER TABLE chats ALTER COLUMN slot_windows SET DEFAULT '{}', ALTER COLUMN slot_windows SET NOT NULL;
+
+
+-- +migrate Down
+ALTER TABLE chats ALTER COLUMN filters DROP NOT NULL, ALTER COLUMN filters DROP DEFAULT;
+ALTER TABLE chats ALTER COLUMN doses DROP NOT NULL, ALTER COLUMN doses DROP DEFAULT;
+ALTER TABLE chats ALTER COLUMN districts DROP NOT NULL, ALTER COLUMN districts DROP DEFAULT;
+ALTER TABLE chats ALTER COLUMN sources DROP NOT NULL, ALTER COLUMN sources DROP DEFAULT;
+ALTER TABLE chats ALTER COLUMN muted_sources DROP NOT NULL, ALTER COLUMN muted_sources DROP DEFAULT;
+ALTER TABLE chats ALTER COLUMN slot_windows DROP NOT NULL, ALTER COLUMN slot_windows DROP DEFAULT;
+UPDATE chats SET filters = NULL WHERE filters = '{}';
+UPDATE chats SET doses = NULL WHERE doses = '{}';
+UPDATE chats SET districts = NULL WHERE districts = '{}';
+UPDATE chats SET sources = NULL WHERE sources = '{}';
+UPDATE chats SET muted_sources = NULL WHERE muted_sources = '{}';
+UPDATE chats SET slot_windows = NULL WHERE slot_windows = '{}';
@@ -0,0 +1,59 @@
+package announcement
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+var (
+	tableName = "announcements"
+
+	fields = []string{
+		"source",
+		"vaccine",
+		"dose",
+		"detail",
+		"fingerprint",
+		"announced_at",
+	}
+)
+
+// Announcement holds the last announcement of the results of a source for a
+// vaccine, dose and detail
+type Announcement struct {
+	Source      string
+	Vaccine     string
+	Dose        string
+	Detail      string
+	Fingerprint string
+	AnnouncedAt time.Time
+}
+
+// Model holds the information for the model
+type Model struct {
+	db *sql.DB
+}
+
+// NewModel returns a new model
+func NewModel(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// getSelectBuilder returns a SELECT statement builder for the announcement model
+func (m *Model) getSelectBuilder() sq.SelectBuilder {
+	return sq.
+		Select(fields...).
+		PlaceholderFormat(sq.Dollar).
+		From(tableName).
+		RunWith(m.db)
+}
+
+// getInsertBuilder returns a INSERT statement builder for the announcement model
+func (m *Model) getInsertBuilder() sq.InsertBuilder {
+	return sq.
+		Insert(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
@@ -0,0 +1,17 @@
+package announcement
+
+// List lists the announcements
+func (m *Model) List() ([]*Announcement, error) {
+	rows, err := m.getSelectBuilder().Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	announcements, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return announcements, nil
+}
@@ -0,0 +1,16 @@
+package announcement
+
+import (
+	"time"
+)
+
+// Save creates or replaces the announcement of the source for the vaccine, dose
+// and detail
+func (m *Model) Save(source, vaccine, dose, detail, fingerprint string, announcedAt time.Time) error {
+	_, err := m.getInsertBuilder().
+		Columns(fields...).
+		Values(source, vaccine, dose, detail, fingerprint, announcedAt).
+		Suffix("ON CONFLICT (source, vaccine, dose, detail) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, announced_at = EXCLUDED.announced_at").
+		Exec()
+	return err
+}
@@ -0,0 +1,38 @@
+package announcement
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func scanRow(scanner sq.RowScanner) (*Announcement, error) {
+	announcement := &Announcement{}
+	err := scanner.Scan(
+		&announcement.Source,
+		&announcement.Vaccine,
+		&announcement.Dose,
+		&announcement.Detail,
+		&announcement.Fingerprint,
+		&announcement.AnnouncedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+func scanRows(rows *sql.Rows) ([]*Announcement, error) {
+	announcements := make([]*Announcement, 0)
+
+	for rows.Next() {
+		announcement, err := scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, announcement)
+	}
+
+	return announcements, rows.Err()
+}
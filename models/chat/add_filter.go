@@ -0,0 +1,26 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AddFilter adds a filter to the filters of the chat
+func (m *Model) AddFilter(id int64, filter string) (*Chat, error) {
+	if filter == "" {
+		return m.Find(id)
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("filters", appendElement("filters", filter)).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
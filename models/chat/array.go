@@ -0,0 +1,39 @@
+package chat
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// appendElement returns the array column with the value appended, unless it is
+// already one of its elements. Being computed by the UPDATE itself, concurrent
+// updates of the column don't overwrite each other.
+func appendElement(column string, value string) sq.Sqlizer {
+	return sq.Expr(
+		"CASE WHEN ?::text = ANY("+column+") THEN "+column+" ELSE array_append("+column+", ?::text) END",
+		value, value,
+	)
+}
+
+// removeElement returns the array column without the value, the empty array
+// when no element is left means no preference
+func removeElement(column string, value string) sq.Sqlizer {
+	return sq.Expr("array_remove("+column+", ?::text)", value)
+}
+
+// noElement is the empty array, which stands for no preference in the array
+// columns
+func noElement() sq.Sqlizer {
+	return sq.Expr("'{}'::text[]")
+}
+
+// hasNoElement matches the rows without preference in the array column, which
+// the GIN index of the column serves like hasAnyElement
+func hasNoElement(column string) sq.Sqlizer {
+	return sq.Expr(column + " = '{}'::text[]")
+}
+
+// hasAnyElement matches the rows having one of the values as element of the
+// array column, which the GIN index of the column serves
+func hasAnyElement(column string, values ...string) sq.Sqlizer {
+	return sq.Expr(column+" && ?::text[]", values)
+}
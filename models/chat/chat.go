@@ -4,8 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/geo"
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
 )
 
 var (
@@ -14,6 +18,26 @@ var (
 	fields = []string{
 		"id",
 		"filters",
+		"doses",
+		"latitude",
+		"longitude",
+		"max_distance",
+		"districts",
+		"days",
+		"slot_windows",
+		"skip_time_unknown",
+		"quiet_start",
+		"quiet_end",
+		"quiet_mode",
+		"timezone",
+		"digest_interval",
+		"digest_sent_at",
+		"min_amount",
+		"min_amounts",
+		"skip_uncounted",
+		"sources",
+		"muted_sources",
+		"language",
 	}
 
 	preparedFields = strings.Join(fields, ", ")
@@ -23,6 +47,63 @@ var (
 type Chat struct {
 	ID      int64
 	Filters []string
+	// Doses are the doses the chat looks for, every dose when empty
+	Doses []vaccines.Dose
+	// Location is the home of the chat, from a shared location or a postcode
+	Location *geo.Point
+	// MaxDistance is the maximum distance in kilometers between the home and
+	// the practices, unlimited when zero
+	MaxDistance float64
+	// Districts are the districts the chat looks for, every district when empty
+	Districts []string
+	// Days is how many days ahead the chat looks for slots, any date when zero
+	Days int
+	// SlotWindows are the times of the week the chat can go to an appointment
+	// in, any time when empty
+	SlotWindows []SlotWindow
+	// SkipTimeUnknown is set when the chat does not want the appointments
+	// whose time is unknown
+	SkipTimeUnknown bool
+	// QuietHours are the hours the chat does not want to be disturbed in, in
+	// its timezone, nil when it has none
+	QuietHours *QuietHours
+	// QuietMode is what happens to the alerts during the quiet hours
+	QuietMode QuietMode
+	// Timezone is the name of the timezone of the chat
+	Timezone string
+	// DigestInterval is the time between two digests of the chat, the alerts
+	// are sent instantly when zero
+	DigestInterval time.Duration
+	// DigestSentAt is when the last digest was sent
+	DigestSentAt *time.Time
+	// MinAmount is the minimum of free slots the chat wants to be alerted of,
+	// no minimum when zero
+	MinAmount int
+	// MinAmounts overrides MinAmount for some vaccines or families of vaccines
+	MinAmounts map[string]int
+	// SkipUncounted is set when the chat has a minimum and does not want the
+	// alerts of the sources that can't count their slots
+	SkipUncounted bool
+	// Sources are the names of the sources the chat subscribed to, every
+	// source when empty
+	Sources []string
+	// MutedSources are the names of the sources the chat does not want
+	MutedSources []string
+	// Language is the language the chat is talked to in, empty until known
+	// which the messages treat as English
+	Language locales.Language
+}
+
+// Threshold returns the minimum of free slots the chat wants to be alerted of
+// for the vaccine, no minimum when zero
+func (c *Chat) Threshold(vaccine vaccines.Vaccine) int {
+	if amount, ok := c.MinAmounts[string(vaccine)]; ok {
+		return amount
+	}
+	if amount, ok := c.MinAmounts[string(vaccine.Family())]; ok {
+		return amount
+	}
+	return c.MinAmount
 }
 
 // Model holds the information for the model
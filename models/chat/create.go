@@ -3,14 +3,15 @@ package chat
 import (
 	"errors"
 
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 )
 
-// Create creates a chat
-func (m *Model) Create(id int64) (*Chat, error) {
+// Create creates a chat talked to in the language
+func (m *Model) Create(id int64, language locales.Language) (*Chat, error) {
 
-	row := m.getInsertBuilder().Columns("id").Values(id).QueryRow()
+	row := m.getInsertBuilder().Columns("id", "language").Values(id, language).QueryRow()
 	chat, err := scanRow(row)
 	if err != nil {
 		var pgErr *pgconn.PgError
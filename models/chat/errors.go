@@ -8,4 +8,13 @@ var (
 
 	// ErrChatNotFound is return when the chat is not found
 	ErrChatNotFound = errors.New("chat not found")
+
+	// ErrInvalidSlotWindow is return when a slot window can't be parsed
+	ErrInvalidSlotWindow = errors.New("invalid slot window")
+
+	// ErrInvalidQuietHours is return when quiet hours can't be parsed
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+
+	// ErrInvalidQuietMode is return when the quiet mode is unknown
+	ErrInvalidQuietMode = errors.New("invalid quiet mode")
 )
@@ -2,17 +2,39 @@ package chat
 
 import (
 	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
 )
 
-// List lists chats
-func (m *Model) List(vaccineName *string) ([]*Chat, error) {
+// earthRadius is the mean radius of the earth in kilometers
+const earthRadius = 6371.0
+
+// List lists the enabled chats, when result is set only the chats looking for
+// its vaccine, its dose, its location, its amount of slots and its source are
+// returned. A result of an unknown dose or location is sent whatever the doses
+// or the location of the chat since it may be the one it looks for.
+func (m *Model) List(result *vaccines.Result) ([]*Chat, error) {
 	q := m.getSelectBuilder().Where(
 		sq.Eq{"enabled": true})
 
-	if vaccineName != nil {
-		q = q.Where(
-			sq.Or{sq.Like{"filters": "%" + *vaccineName + "%"}, sq.Eq{"filters": nil}},
-		)
+	if result != nil {
+		q = q.Where(sq.Or{hasNoElement("filters"), hasAnyElement("filters", result.Vaccine.Filters()...)})
+
+		if result.Dose != vaccines.DoseUnknown {
+			q = q.Where(sq.Or{hasNoElement("doses"), hasAnyElement("doses", string(result.Dose))})
+		}
+
+		if result.TimeUnknown() {
+			q = q.Where(sq.Eq{"skip_time_unknown": false})
+		}
+
+		if where := whereLocation(result); where != nil {
+			q = q.Where(where)
+		}
+
+		q = q.Where(whereAmount(result))
+
+		q = q.Where(sq.Or{hasNoElement("sources"), hasAnyElement("sources", result.Source)})
+		q = q.Where(sq.Expr("NOT (?)", hasAnyElement("muted_sources", result.Source)))
 	}
 	rows, err := q.Query()
 	if err != nil {
@@ -26,3 +48,46 @@ func (m *Model) List(vaccineName *string) ([]*Chat, error) {
 
 	return chats, nil
 }
+
+// whereLocation matches the chats without location preference, the ones
+// looking for the district of the result and the ones living close enough to
+// it. It returns nil when the result has no location.
+func whereLocation(result *vaccines.Result) sq.Sqlizer {
+	hasCoordinates := result.Latitude != 0 || result.Longitude != 0
+	if result.District == "" && !hasCoordinates {
+		return nil
+	}
+
+	or := sq.Or{
+		sq.And{
+			hasNoElement("districts"),
+			sq.Or{sq.Eq{"max_distance": nil}, sq.Eq{"latitude": nil}},
+		},
+	}
+	if result.District != "" {
+		or = append(or, hasAnyElement("districts", result.District))
+	}
+	if hasCoordinates {
+		or = append(or, sq.Expr(
+			`(max_distance IS NOT NULL AND latitude IS NOT NULL AND
+			? * acos(least(1.0, sin(radians(latitude)) * sin(radians(?)) +
+			cos(radians(latitude)) * cos(radians(?)) * cos(radians(longitude - ?)))) <= max_distance)`,
+			earthRadius, result.Latitude, result.Latitude, result.Longitude,
+		))
+	}
+	return or
+}
+
+// whereAmount matches the chats without minimum of free slots for the vaccine
+// of the result and the ones whose minimum it reaches. A result whose amount is
+// unknown only matches the chats that did not choose to skip them.
+func whereAmount(result *vaccines.Result) sq.Sqlizer {
+	threshold := sq.Expr(
+		"COALESCE((min_amounts ->> ?)::int, (min_amounts ->> ?)::int, min_amount)",
+		string(result.Vaccine), string(result.Vaccine.Family()),
+	)
+	if result.Amount > 0 {
+		return sq.Expr("(? IS NULL OR ? <= ?)", threshold, threshold, result.Amount)
+	}
+	return sq.Expr("(? IS NULL OR skip_uncounted = false)", threshold)
+}
@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListDigestDue lists the enabled chats whose digest is due at now and holds
+// at least one entry
+func (m *Model) ListDigestDue(now time.Time) ([]*Chat, error) {
+	rows, err := m.getSelectBuilder().
+		Where(sq.Eq{"enabled": true}).
+		Where(sq.NotEq{"digest_interval": nil}).
+		Where(sq.Or{
+			sq.Eq{"digest_sent_at": nil},
+			sq.Expr("digest_sent_at + make_interval(mins => digest_interval) <= ?", now),
+		}).
+		Where("EXISTS (SELECT 1 FROM digest_entries WHERE digest_entries.chat_id = chats.id)").
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chats, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return chats, nil
+}
@@ -0,0 +1,68 @@
+package chat
+
+import (
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// berlin is the timezone the days of the chats are counted in
+var berlin, _ = time.LoadLocation("Europe/Berlin")
+
+// Match returns the result restricted to the slots the chat looks for, by date
+// and by time of the week, or nil when none of them fits or when fewer slots
+// than the minimum of the chat are left. A result without slots is returned as
+// is since its dates are unknown.
+func (c *Chat) Match(result *vaccines.Result, now time.Time) *vaccines.Result {
+	if len(result.Slots) == 0 {
+		return result
+	}
+
+	var slots []time.Time
+	for _, slot := range result.Slots {
+		if c.inWindow(slot, now) && c.inSlotWindows(slot, result.DateOnly) {
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	if len(slots) == len(result.Slots) {
+		return result
+	}
+
+	matched := *result
+	matched.SetSlots(slots)
+	if matched.Amount != 0 {
+		matched.Amount = int64(len(slots))
+		if matched.Amount < int64(c.Threshold(result.Vaccine)) {
+			return nil
+		}
+	}
+	return &matched
+}
+
+// inSlotWindows reports whether the slot is in one of the slot windows of the
+// chat, only its day is looked at when its time is unknown
+func (c *Chat) inSlotWindows(slot time.Time, dateOnly bool) bool {
+	if len(c.SlotWindows) == 0 {
+		return true
+	}
+	for _, window := range c.SlotWindows {
+		if window.contains(slot, dateOnly) {
+			return true
+		}
+	}
+	return false
+}
+
+// inWindow reports whether the slot is within the days the chat looks for,
+// the last day being counted whole
+func (c *Chat) inWindow(slot time.Time, now time.Time) bool {
+	if c.Days == 0 {
+		return true
+	}
+	today := now.In(berlin)
+	end := time.Date(today.Year(), today.Month(), today.Day()+c.Days+1, 0, 0, 0, 0, berlin)
+	return slot.Before(end)
+}
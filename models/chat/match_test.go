@@ -0,0 +1,119 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+func TestMatch(t *testing.T) {
+	// the 16th of October 2026 is a Friday
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, berlin)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, berlin)
+	}
+	evenings, _ := ParseSlotWindow("mon-fri 17:00-24:00")
+	result := func(amount int64, dateOnly bool, slots ...time.Time) *vaccines.Result {
+		r := &vaccines.Result{Vaccine: vaccines.Pfizer, Amount: amount, DateOnly: dateOnly}
+		r.SetSlots(slots)
+		return r
+	}
+
+	tests := []struct {
+		name       string
+		chat       Chat
+		result     *vaccines.Result
+		wantNil    bool
+		wantSame   bool
+		wantSlots  int
+		wantAmount int64
+	}{
+		{
+			name:     "no preference",
+			chat:     Chat{},
+			result:   result(2, false, at(16, 10), at(19, 18)),
+			wantSame: true,
+		},
+		{
+			name:     "time unknown",
+			chat:     Chat{Days: 1, SlotWindows: []SlotWindow{evenings}, MinAmount: 10},
+			result:   result(0, false),
+			wantSame: true,
+		},
+		{
+			name:       "restricted to the windows",
+			chat:       Chat{SlotWindows: []SlotWindow{evenings}},
+			result:     result(3, false, at(16, 10), at(16, 18), at(17, 18)),
+			wantSlots:  1,
+			wantAmount: 1,
+		},
+		{
+			name:       "restricted to the days",
+			chat:       Chat{Days: 1},
+			result:     result(3, false, at(16, 10), at(17, 23), at(18, 0)),
+			wantSlots:  2,
+			wantAmount: 2,
+		},
+		{
+			name:    "no slot in the windows",
+			chat:    Chat{SlotWindows: []SlotWindow{evenings}},
+			result:  result(1, false, at(17, 18)),
+			wantNil: true,
+		},
+		{
+			name:     "date only in the windows",
+			chat:     Chat{SlotWindows: []SlotWindow{evenings}},
+			result:   result(2, true, at(16, 0), at(19, 0)),
+			wantSame: true,
+		},
+		{
+			name:       "date only restricted to the days of the windows",
+			chat:       Chat{SlotWindows: []SlotWindow{evenings}},
+			result:     result(2, true, at(16, 0), at(17, 0)),
+			wantSlots:  1,
+			wantAmount: 1,
+		},
+		{
+			name:    "fewer slots left than the minimum",
+			chat:    Chat{SlotWindows: []SlotWindow{evenings}, MinAmount: 2},
+			result:  result(3, false, at(16, 10), at(16, 11), at(16, 18)),
+			wantNil: true,
+		},
+		{
+			name:       "minimum of the vaccine",
+			chat:       Chat{SlotWindows: []SlotWindow{evenings}, MinAmount: 5, MinAmounts: map[string]int{"pfizer": 1}},
+			result:     result(3, false, at(16, 10), at(16, 11), at(16, 18)),
+			wantSlots:  1,
+			wantAmount: 1,
+		},
+		{
+			name:       "uncounted result under the minimum",
+			chat:       Chat{SlotWindows: []SlotWindow{evenings}, MinAmount: 5},
+			result:     result(0, false, at(16, 10), at(16, 18)),
+			wantSlots:  1,
+			wantAmount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.chat.Match(tt.result, now)
+			switch {
+			case tt.wantNil:
+				if got != nil {
+					t.Errorf("Match() = %d slots, want nil", len(got.Slots))
+				}
+			case got == nil:
+				t.Fatal("Match() = nil")
+			case tt.wantSame:
+				if got != tt.result {
+					t.Errorf("Match() restricted the result to %d slots", len(got.Slots))
+				}
+			default:
+				if len(got.Slots) != tt.wantSlots || got.Amount != tt.wantAmount {
+					t.Errorf("Match() = %d slots and amount %d, want %d and %d", len(got.Slots), got.Amount, tt.wantSlots, tt.wantAmount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuietMode is what happens to the alerts sent during the quiet hours of a chat
+type QuietMode string
+
+const (
+	// QuietDrop drops the alerts
+	QuietDrop QuietMode = "drop"
+	// QuietSilent sends the alerts without notification
+	QuietSilent QuietMode = "silent"
+	// QuietQueue delivers the alerts at the end of the quiet hours
+	QuietQueue QuietMode = "queue"
+)
+
+// defaultTimezone is the timezone of the chats that did not choose one
+const defaultTimezone = "Europe/Berlin"
+
+// QuietHours is the time of the day the chat does not want to be disturbed
+// in, it can span midnight like 22:00-07:00
+type QuietHours struct {
+	// Start and End are the minutes since midnight the quiet hours start and end at
+	Start int
+	End   int
+}
+
+// ParseQuietHours parses quiet hours like "22:00-07:00", the quiet hours must
+// end at another time than they start
+func ParseQuietHours(text string) (QuietHours, error) {
+	bounds := strings.SplitN(strings.TrimSpace(text), "-", 2)
+	if len(bounds) != 2 {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	start, err := parseClock(bounds[0])
+	if err != nil {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	end, err := parseClock(bounds[1])
+	if err != nil {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	// quiet hours lasting the whole day, like "00:00-24:00", would never end
+	start, end = start%minutesPerDay, end%minutesPerDay
+	if start == end {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	return QuietHours{Start: start, End: end}, nil
+}
+
+// String formats the quiet hours the way ParseQuietHours parses them
+func (q QuietHours) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", q.Start/60, q.Start%60, q.End/60, q.End%60)
+}
+
+// TimeLocation returns the timezone of the chat
+func (c *Chat) TimeLocation() *time.Location {
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return berlin
+	}
+	return location
+}
+
+// QuietUntil returns the end of the quiet hours of the chat when now is in them
+func (c *Chat) QuietUntil(now time.Time) (time.Time, bool) {
+	if c.QuietHours == nil {
+		return time.Time{}, false
+	}
+	local := now.In(c.TimeLocation())
+	clock := local.Hour()*60 + local.Minute()
+	start, end := c.QuietHours.Start, c.QuietHours.End
+
+	var day int
+	switch {
+	case start < end && clock >= start && clock < end:
+	case start > end && clock < end:
+	case start > end && clock >= start:
+		day = 1
+	default:
+		return time.Time{}, false
+	}
+	until := time.Date(local.Year(), local.Month(), local.Day()+day, end/60, end%60, 0, 0, local.Location())
+	return until, true
+}
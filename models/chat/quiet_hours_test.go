@@ -0,0 +1,94 @@
+package chat
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    QuietHours
+		wantErr error
+	}{
+		{text: "22:00-07:00", want: QuietHours{Start: 22 * 60, End: 7 * 60}},
+		{text: " 13:30-14:15 ", want: QuietHours{Start: 13*60 + 30, End: 14*60 + 15}},
+		{text: "22-7", want: QuietHours{Start: 22 * 60, End: 7 * 60}},
+		{text: "20:00-24:00", want: QuietHours{Start: 20 * 60, End: 0}},
+		{text: "00:00-24:00", wantErr: ErrInvalidQuietHours},
+		{text: "22:00-22:00", wantErr: ErrInvalidQuietHours},
+		{text: "24:00-00:00", wantErr: ErrInvalidQuietHours},
+		{text: "22:00", wantErr: ErrInvalidQuietHours},
+		{text: "25:00-07:00", wantErr: ErrInvalidQuietHours},
+		{text: "22:60-07:00", wantErr: ErrInvalidQuietHours},
+		{text: "night", wantErr: ErrInvalidQuietHours},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseQuietHours(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseQuietHours(%q) error = %v, want %v", tt.text, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseQuietHours(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietUntil(t *testing.T) {
+	night := &QuietHours{Start: 22 * 60, End: 7 * 60}
+	lunch := &QuietHours{Start: 12 * 60, End: 13*60 + 30}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name      string
+		quiet     *QuietHours
+		timezone  string
+		now       time.Time
+		wantQuiet bool
+		wantUntil time.Time
+	}{
+		{name: "no quiet hours", quiet: nil, now: at(18, 23, 0)},
+		{name: "before the night", quiet: night, now: at(18, 21, 59)},
+		{name: "night before midnight", quiet: night, now: at(18, 22, 0), wantQuiet: true, wantUntil: at(19, 7, 0)},
+		{name: "night after midnight", quiet: night, now: at(19, 3, 0), wantQuiet: true, wantUntil: at(19, 7, 0)},
+		{name: "end of the night", quiet: night, now: at(19, 7, 0)},
+		{name: "lunch", quiet: lunch, now: at(18, 12, 45), wantQuiet: true, wantUntil: at(18, 13, 30)},
+		{name: "after lunch", quiet: lunch, now: at(18, 13, 30)},
+		{
+			name:      "timezone of the chat",
+			quiet:     night,
+			timezone:  "America/New_York",
+			now:       time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC),
+			wantQuiet: false,
+		},
+		{
+			name:      "night in the timezone of the chat",
+			quiet:     night,
+			timezone:  "America/New_York",
+			now:       time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timezone := tt.timezone
+			if timezone == "" {
+				timezone = defaultTimezone
+			}
+			c := &Chat{QuietHours: tt.quiet, Timezone: timezone}
+			until, quiet := c.QuietUntil(tt.now)
+			if quiet != tt.wantQuiet {
+				t.Fatalf("QuietUntil(%s) quiet = %v, want %v", tt.now, quiet, tt.wantQuiet)
+			}
+			if quiet && !until.Equal(tt.wantUntil) {
+				t.Errorf("QuietUntil(%s) = %s, want %s", tt.now, until, tt.wantUntil)
+			}
+		})
+	}
+}
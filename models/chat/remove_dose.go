@@ -0,0 +1,24 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// RemoveDose removes a dose from the doses the chat looks for, the chat looks
+// for every dose again when it removes the last one
+func (m *Model) RemoveDose(id int64, dose vaccines.Dose) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("doses", removeElement("doses", string(dose))).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RemoveFilter removes a filter from the filters of the chat, the chat looks
+// for every vaccine again when it removes the last one
+func (m *Model) RemoveFilter(id int64, filter string) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("filters", removeElement("filters", filter)).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RemoveSlotWindow removes a window from the slot windows of the chat, the
+// chat looks for slots at any time again when it removes the last one
+func (m *Model) RemoveSlotWindow(id int64, window SlotWindow) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("slot_windows", removeElement("slot_windows", window.String())).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
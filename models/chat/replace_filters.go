@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ReplaceFilters replaces the filters of the chat, no filter resets them so
+// the chat looks for every vaccine again
+func (m *Model) ReplaceFilters(id int64, filters []string) (*Chat, error) {
+	var newFilters []string
+	for _, filter := range filters {
+		if filter != "" && !isAlreadyFilter(newFilters, filter) {
+			newFilters = append(newFilters, filter)
+		}
+	}
+
+	var value interface{} = newFilters
+	if len(newFilters) == 0 {
+		value = noElement()
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("filters", value).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+func isAlreadyFilter(filters []string, toAdd string) bool {
+	for _, filter := range filters {
+		if filter == toAdd {
+			return true
+		}
+	}
+	return false
+}
@@ -2,30 +2,110 @@ package chat
 
 import (
 	"database/sql"
-	"strings"
+	"encoding/json"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/geo"
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+	"github.com/jackc/pgtype"
 )
 
 func scanRow(scanner sq.RowScanner) (*Chat, error) {
-	var filters *string
+	var (
+		doses, slotWindows            []string
+		latitude, longitude, distance *float64
+		days, quietStart, quietEnd    *int
+		digestInterval, minAmount     *int
+		minAmounts                    []byte
+		language                      *string
+	)
 
 	chat := &Chat{}
 	err := scanner.Scan(
 		&chat.ID,
-		&filters,
+		&stringArray{&chat.Filters},
+		&stringArray{&doses},
+		&latitude,
+		&longitude,
+		&distance,
+		&stringArray{&chat.Districts},
+		&days,
+		&stringArray{&slotWindows},
+		&chat.SkipTimeUnknown,
+		&quietStart,
+		&quietEnd,
+		&chat.QuietMode,
+		&chat.Timezone,
+		&digestInterval,
+		&chat.DigestSentAt,
+		&minAmount,
+		&minAmounts,
+		&chat.SkipUncounted,
+		&stringArray{&chat.Sources},
+		&stringArray{&chat.MutedSources},
+		&language,
 	)
-
-	if filters != nil {
-		chat.Filters = strings.Split(*filters, ",")
-	}
 	if err != nil {
 		return nil, err
 	}
 
+	for _, dose := range doses {
+		chat.Doses = append(chat.Doses, vaccines.Dose(dose))
+	}
+	if latitude != nil && longitude != nil {
+		chat.Location = &geo.Point{Latitude: *latitude, Longitude: *longitude}
+	}
+	if distance != nil {
+		chat.MaxDistance = *distance
+	}
+	if quietStart != nil && quietEnd != nil {
+		chat.QuietHours = &QuietHours{Start: *quietStart, End: *quietEnd}
+	}
+	if minAmount != nil {
+		chat.MinAmount = *minAmount
+	}
+	if minAmounts != nil {
+		err := json.Unmarshal(minAmounts, &chat.MinAmounts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if language != nil {
+		chat.Language = locales.Language(*language)
+	}
+	if digestInterval != nil {
+		chat.DigestInterval = time.Duration(*digestInterval) * time.Minute
+	}
+	if days != nil {
+		chat.Days = *days
+	}
+	for _, text := range slotWindows {
+		window, err := ParseSlotWindow(text)
+		if err == nil {
+			chat.SlotWindows = append(chat.SlotWindows, window)
+		}
+	}
+
 	return chat, nil
 }
 
+// stringArray scans a TEXT[] column to the slice
+type stringArray struct {
+	dst *[]string
+}
+
+// Scan implements the sql.Scanner interface
+func (a *stringArray) Scan(src interface{}) error {
+	var array pgtype.TextArray
+	err := array.Scan(src)
+	if err != nil {
+		return err
+	}
+	return array.AssignTo(a.dst)
+}
+
 func scanRows(rows *sql.Rows) ([]*Chat, error) {
 	chats := make([]*Chat, 0)
 
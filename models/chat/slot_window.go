@@ -0,0 +1,174 @@
+package chat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minutesPerDay is the end of a slot window lasting until midnight
+const minutesPerDay = 24 * 60
+
+// weekdays maps the English and German names of the days to their weekday
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "so": time.Sunday,
+	"mon": time.Monday, "mo": time.Monday,
+	"tue": time.Tuesday, "di": time.Tuesday,
+	"wed": time.Wednesday, "mi": time.Wednesday,
+	"thu": time.Thursday, "do": time.Thursday,
+	"fri": time.Friday, "fr": time.Friday,
+	"sat": time.Saturday, "sa": time.Saturday,
+}
+
+// dayGroups maps the names of groups of days to the days they contain
+var dayGroups = map[string]string{
+	"weekdays": "mon-fri",
+	"werktags": "mon-fri",
+	"weekend":  "sat-sun",
+	"daily":    "mon-sun",
+	"any":      "mon-sun",
+}
+
+// SlotWindow is a range of time, on some days of the week, the chat can go to
+// an appointment in
+type SlotWindow struct {
+	// Days are the days of the window indexed by weekday
+	Days [7]bool
+	// Start and End are the minutes since midnight the window starts and ends at,
+	// the window crosses midnight when End is before Start
+	Start int
+	End   int
+}
+
+// ParseSlotWindow parses a window like "mon-fri 17:00-24:00", "weekend" or
+// "sa+so 10:00-14:00", the window lasts the whole day without time range. A
+// time range ending before it starts, like "fri 22:00-02:00", crosses midnight
+// and ends on the next day.
+func ParseSlotWindow(text string) (SlotWindow, error) {
+	window := SlotWindow{End: minutesPerDay}
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 || len(fields) > 2 {
+		return window, ErrInvalidSlotWindow
+	}
+
+	for _, item := range strings.Split(fields[0], "+") {
+		if group, ok := dayGroups[item]; ok {
+			item = group
+		}
+		bounds := strings.SplitN(item, "-", 2)
+		from, ok := weekdays[bounds[0]]
+		if !ok {
+			return window, ErrInvalidSlotWindow
+		}
+		to := from
+		if len(bounds) == 2 {
+			to, ok = weekdays[bounds[1]]
+			if !ok {
+				return window, ErrInvalidSlotWindow
+			}
+		}
+		for day := from; ; day = (day + 1) % 7 {
+			window.Days[day] = true
+			if day == to {
+				break
+			}
+		}
+	}
+
+	if len(fields) == 2 {
+		bounds := strings.SplitN(fields[1], "-", 2)
+		if len(bounds) != 2 {
+			return window, ErrInvalidSlotWindow
+		}
+		var err error
+		window.Start, err = parseClock(bounds[0])
+		if err != nil {
+			return window, err
+		}
+		window.End, err = parseClock(bounds[1])
+		if err != nil {
+			return window, err
+		}
+		if window.Start == window.End || window.Start == minutesPerDay {
+			return window, ErrInvalidSlotWindow
+		}
+	}
+	return window, nil
+}
+
+// parseClock parses a time of the day like "17:00" or "17" to minutes since midnight
+func parseClock(text string) (int, error) {
+	parts := strings.SplitN(text, ":", 2)
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, ErrInvalidSlotWindow
+	}
+	minutes := 0
+	if len(parts) == 2 {
+		minutes, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, ErrInvalidSlotWindow
+		}
+	}
+	clock := hours*60 + minutes
+	if hours < 0 || minutes < 0 || minutes >= 60 || clock > minutesPerDay {
+		return 0, ErrInvalidSlotWindow
+	}
+	return clock, nil
+}
+
+// String formats the window the way ParseSlotWindow parses it
+func (w SlotWindow) String() string {
+	var ranges []string
+	// walk the week from monday so "sat-sun" is one range
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((i + 1) % 7)
+		if !w.Days[day] {
+			continue
+		}
+		last := i
+		for last+1 < 7 && w.Days[time.Weekday((last+2)%7)] {
+			last++
+		}
+		name := dayName(day)
+		if last > i {
+			name += "-" + dayName(time.Weekday((last+1)%7))
+		}
+		ranges = append(ranges, name)
+		i = last
+	}
+	days := strings.Join(ranges, "+")
+	if w.Start == 0 && w.End == minutesPerDay {
+		return days
+	}
+	return fmt.Sprintf("%s %02d:%02d-%02d:%02d", days, w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
+
+func dayName(day time.Weekday) string {
+	return strings.ToLower(day.String()[:3])
+}
+
+// contains reports whether the slot is in the window, only its day is looked
+// at when its time is unknown. The early hours of a window crossing midnight
+// belong to the day before.
+func (w SlotWindow) contains(slot time.Time, dateOnly bool) bool {
+	slot = slot.In(berlin)
+	day := slot.Weekday()
+	previous := (day + 6) % 7
+	crosses := w.End < w.Start
+	if dateOnly {
+		return w.Days[day] || crosses && w.Days[previous]
+	}
+
+	clock := slot.Hour()*60 + slot.Minute()
+	switch {
+	case !crosses:
+		return w.Days[day] && clock >= w.Start && clock < w.End
+	case clock >= w.Start:
+		return w.Days[day]
+	case clock < w.End:
+		return w.Days[previous]
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSlotWindow(t *testing.T) {
+	weekdays := [7]bool{false, true, true, true, true, true, false}
+	weekend := [7]bool{true, false, false, false, false, false, true}
+	friday := [7]bool{time.Friday: true}
+
+	tests := []struct {
+		text    string
+		want    SlotWindow
+		wantErr error
+	}{
+		{text: "mon-fri 17:00-24:00", want: SlotWindow{Days: weekdays, Start: 17 * 60, End: minutesPerDay}},
+		{text: "weekend", want: SlotWindow{Days: weekend, End: minutesPerDay}},
+		{text: "sa+so 10:00-14:00", want: SlotWindow{Days: weekend, Start: 10 * 60, End: 14 * 60}},
+		{text: "sat-sun", want: SlotWindow{Days: weekend, End: minutesPerDay}},
+		{text: "Fri 22:00-02:00", want: SlotWindow{Days: friday, Start: 22 * 60, End: 2 * 60}},
+		{text: "fri 22-0", want: SlotWindow{Days: friday, Start: 22 * 60, End: 0}},
+		{text: "", wantErr: ErrInvalidSlotWindow},
+		{text: "someday", wantErr: ErrInvalidSlotWindow},
+		{text: "mon-someday", wantErr: ErrInvalidSlotWindow},
+		{text: "mon 17:00", wantErr: ErrInvalidSlotWindow},
+		{text: "mon 17:00-17:00", wantErr: ErrInvalidSlotWindow},
+		{text: "mon 24:00-02:00", wantErr: ErrInvalidSlotWindow},
+		{text: "mon 25:00-26:00", wantErr: ErrInvalidSlotWindow},
+		{text: "mon 17:00-18:00 extra", wantErr: ErrInvalidSlotWindow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseSlotWindow(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSlotWindow(%q) error = %v, want %v", tt.text, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseSlotWindow(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+			again, err := ParseSlotWindow(got.String())
+			if err != nil || again != got {
+				t.Errorf("ParseSlotWindow(%q) = %+v, %v, want %+v", got.String(), again, err, got)
+			}
+		})
+	}
+}
+
+func TestSlotWindowContains(t *testing.T) {
+	// the 16th of October 2026 is a Friday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, berlin)
+	}
+	evenings, _ := ParseSlotWindow("mon-fri 17:00-24:00")
+	fridayNight, _ := ParseSlotWindow("fri 22:00-02:00")
+
+	tests := []struct {
+		name     string
+		window   SlotWindow
+		slot     time.Time
+		dateOnly bool
+		want     bool
+	}{
+		{name: "in the evening", window: evenings, slot: at(16, 18, 0), want: true},
+		{name: "before the evening", window: evenings, slot: at(16, 16, 59), want: false},
+		{name: "last minute of the day", window: evenings, slot: at(16, 23, 59), want: true},
+		{name: "evening of another day", window: evenings, slot: at(17, 18, 0), want: false},
+		{name: "night before midnight", window: fridayNight, slot: at(16, 23, 0), want: true},
+		{name: "night after midnight", window: fridayNight, slot: at(17, 1, 0), want: true},
+		{name: "end of the night", window: fridayNight, slot: at(17, 2, 0), want: false},
+		{name: "early hours of the day of the window", window: fridayNight, slot: at(16, 1, 0), want: false},
+		{name: "date only on a day of the window", window: evenings, slot: at(16, 0, 0), dateOnly: true, want: true},
+		{name: "date only on another day", window: evenings, slot: at(17, 0, 0), dateOnly: true, want: false},
+		{name: "date only on the day after a night", window: fridayNight, slot: at(17, 0, 0), dateOnly: true, want: true},
+		{name: "in another timezone", window: evenings, slot: time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.contains(tt.slot, tt.dateOnly); got != tt.want {
+				t.Errorf("contains(%s, %v) = %v, want %v", tt.slot, tt.dateOnly, got, tt.want)
+			}
+		})
+	}
+}
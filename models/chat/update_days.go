@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateDays update how many days ahead the chat looks for slots, zero removes
+// the limit
+func (m *Model) UpdateDays(id int64, days int) (*Chat, error) {
+	var newDays *int
+	if days > 0 {
+		newDays = &days
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("days", newDays).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,34 @@
+package chat
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateDigestInterval update the time between two digests of the chat, zero
+// sends the alerts instantly again. The first digest is sent an interval later.
+func (m *Model) UpdateDigestInterval(id int64, interval time.Duration) (*Chat, error) {
+	var minutes *int
+	if interval > 0 {
+		tmp := int(interval / time.Minute)
+		minutes = &tmp
+	}
+
+	row := m.getUpdateBuilder().
+		Where(sq.Eq{"id": id}).
+		Set("digest_interval", minutes).
+		Set("digest_sent_at", time.Now()).
+		QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
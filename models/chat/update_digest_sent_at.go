@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateDigestSentAt update when the last digest of the chat was sent
+func (m *Model) UpdateDigestSentAt(id int64, sentAt time.Time) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("digest_sent_at", sentAt).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
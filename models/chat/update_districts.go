@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateDistricts adds a district to the districts the chat looks for, the
+// empty string resets them
+func (m *Model) UpdateDistricts(id int64, district string) (*Chat, error) {
+	newDistricts := noElement()
+	if district != "" {
+		newDistricts = appendElement("districts", district)
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("districts", newDistricts).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
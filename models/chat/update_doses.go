@@ -0,0 +1,29 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// UpdateDoses adds a dose to the doses the chat looks for, DoseUnknown resets
+// them so the chat looks for every dose again
+func (m *Model) UpdateDoses(id int64, dose vaccines.Dose) (*Chat, error) {
+	newDoses := noElement()
+	if dose != vaccines.DoseUnknown {
+		newDoses = appendElement("doses", string(dose))
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("doses", newDoses).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+)
+
+// UpdateLanguage update the language the chat is talked to in
+func (m *Model) UpdateLanguage(id int64, language locales.Language) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("language", language).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
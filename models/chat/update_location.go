@@ -0,0 +1,33 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/geo"
+)
+
+// UpdateLocation update the home of the chat, nil removes it
+func (m *Model) UpdateLocation(id int64, location *geo.Point) (*Chat, error) {
+	var latitude, longitude *float64
+	if location != nil {
+		latitude = &location.Latitude
+		longitude = &location.Longitude
+	}
+
+	row := m.getUpdateBuilder().
+		Where(sq.Eq{"id": id}).
+		Set("latitude", latitude).
+		Set("longitude", longitude).
+		QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateMaxDistance update the maximum distance in kilometers between the home
+// of the chat and the practices, zero removes the limit
+func (m *Model) UpdateMaxDistance(id int64, distance float64) (*Chat, error) {
+	var newDistance *float64
+	if distance > 0 {
+		newDistance = &distance
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("max_distance", newDistance).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateMinAmount update the minimum of free slots the chat wants to be alerted
+// of. The minimum is set for a vaccine or a family of vaccines when vaccine is
+// set, for every vaccine otherwise, zero removes it.
+func (m *Model) UpdateMinAmount(id int64, vaccine string, amount int) (*Chat, error) {
+	q := m.getUpdateBuilder().Where(sq.Eq{"id": id})
+	switch {
+	case vaccine == "":
+		var newAmount *int
+		if amount > 0 {
+			newAmount = &amount
+		}
+		q = q.Set("min_amount", newAmount)
+	case amount > 0:
+		q = q.Set("min_amounts", sq.Expr(
+			"COALESCE(min_amounts, '{}'::jsonb) || jsonb_build_object(?::text, ?::int)",
+			vaccine, amount,
+		))
+	default:
+		q = q.Set("min_amounts", sq.Expr("NULLIF(min_amounts - ?::text, '{}'::jsonb)", vaccine))
+	}
+
+	row := q.QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateQuietHours update the quiet hours of the chat, nil removes them
+func (m *Model) UpdateQuietHours(id int64, quietHours *QuietHours) (*Chat, error) {
+	var start, end *int
+	if quietHours != nil {
+		start = &quietHours.Start
+		end = &quietHours.End
+	}
+
+	row := m.getUpdateBuilder().
+		Where(sq.Eq{"id": id}).
+		Set("quiet_start", start).
+		Set("quiet_end", end).
+		QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
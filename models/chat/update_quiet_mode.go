@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateQuietMode update what happens to the alerts during the quiet hours of the chat
+func (m *Model) UpdateQuietMode(id int64, mode QuietMode) (*Chat, error) {
+	switch mode {
+	case QuietDrop, QuietSilent, QuietQueue:
+	default:
+		return nil, ErrInvalidQuietMode
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("quiet_mode", mode).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
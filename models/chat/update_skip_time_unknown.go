@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateSkipTimeUnknown update whether the chat skips the appointments whose
+// time is unknown
+func (m *Model) UpdateSkipTimeUnknown(id int64, skip bool) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("skip_time_unknown", skip).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateSkipUncounted update whether the chat skips the alerts of the sources
+// that can't count their slots when it has a minimum
+func (m *Model) UpdateSkipUncounted(id int64, skip bool) (*Chat, error) {
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("skip_uncounted", skip).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
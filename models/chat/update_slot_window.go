@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateSlotWindows adds a window to the slot windows of the chat, nil resets
+// them so the chat looks for slots at any time again
+func (m *Model) UpdateSlotWindows(id int64, window *SlotWindow) (*Chat, error) {
+	newWindows := noElement()
+	if window != nil {
+		newWindows = appendElement("slot_windows", window.String())
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("slot_windows", newWindows).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateSources adds or removes a source from the sources the chat subscribed
+// to, the empty string resets them
+func (m *Model) UpdateSources(id int64, source string, subscribed bool) (*Chat, error) {
+	return m.updateSourceList(id, "sources", source, subscribed)
+}
+
+// UpdateMutedSources mutes or unmutes a source for the chat, the empty string
+// unmutes every source
+func (m *Model) UpdateMutedSources(id int64, source string, muted bool) (*Chat, error) {
+	return m.updateSourceList(id, "muted_sources", source, muted)
+}
+
+// updateSourceList adds or removes a source from the array column
+func (m *Model) updateSourceList(id int64, column string, source string, add bool) (*Chat, error) {
+	names := noElement()
+	switch {
+	case source == "":
+	case add:
+		names = appendElement(column, source)
+	default:
+		names = removeElement(column, source)
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set(column, names).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateTimezone update the timezone of the chat, the empty string sets it
+// back to Europe/Berlin
+func (m *Model) UpdateTimezone(id int64, timezone string) (*Chat, error) {
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+	_, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	row := m.getUpdateBuilder().Where(sq.Eq{"id": id}).Set("timezone", timezone).QueryRow()
+	chat, err := scanRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChatNotFound
+		}
+
+		return nil, err
+	}
+
+	return chat, nil
+}
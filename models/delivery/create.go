@@ -0,0 +1,14 @@
+package delivery
+
+import (
+	"time"
+)
+
+// Create queues a message for the chat until deliverAt
+func (m *Model) Create(chatID int64, message string, deliverAt time.Time) error {
+	_, err := m.getInsertBuilder().
+		Columns("chat_id", "message", "deliver_at").
+		Values(chatID, message, deliverAt).
+		Exec()
+	return err
+}
@@ -0,0 +1,20 @@
+package delivery
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Delete deletes a delivered message
+func (m *Model) Delete(id int64) error {
+	_, err := m.getDeleteBuilder().Where(sq.Eq{"id": id}).Exec()
+	return err
+}
+
+// DeleteDue deletes the messages due at now that are left, like the ones of
+// the disabled chats
+func (m *Model) DeleteDue(now time.Time) error {
+	_, err := m.getDeleteBuilder().Where(sq.LtOrEq{"deliver_at": now}).Exec()
+	return err
+}
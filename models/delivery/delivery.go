@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+var (
+	tableName = "deliveries"
+
+	fields = []string{
+		"id",
+		"chat_id",
+		"message",
+		"deliver_at",
+	}
+)
+
+// Delivery holds a message waiting for the end of the quiet hours of its chat
+type Delivery struct {
+	ID        int64
+	ChatID    int64
+	Message   string
+	DeliverAt time.Time
+}
+
+// Model holds the information for the model
+type Model struct {
+	db *sql.DB
+}
+
+// NewModel returns a new model
+func NewModel(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// getSelectBuilder returns a SELECT statement builder for the delivery model
+func (m *Model) getSelectBuilder() sq.SelectBuilder {
+	return sq.
+		Select(fields...).
+		PlaceholderFormat(sq.Dollar).
+		From(tableName).
+		RunWith(m.db)
+}
+
+// getInsertBuilder returns a INSERT statement builder for the delivery model
+func (m *Model) getInsertBuilder() sq.InsertBuilder {
+	return sq.
+		Insert(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
+
+// getDeleteBuilder returns a DELETE statement builder for the delivery model
+func (m *Model) getDeleteBuilder() sq.DeleteBuilder {
+	return sq.
+		Delete(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
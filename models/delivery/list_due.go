@@ -0,0 +1,27 @@
+package delivery
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListDue lists the messages to deliver at now to the enabled chats, oldest first
+func (m *Model) ListDue(now time.Time) ([]*Delivery, error) {
+	rows, err := m.getSelectBuilder().
+		Where(sq.LtOrEq{"deliver_at": now}).
+		Where("chat_id IN (SELECT id FROM chats WHERE enabled)").
+		OrderBy("id").
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
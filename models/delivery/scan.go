@@ -0,0 +1,36 @@
+package delivery
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func scanRow(scanner sq.RowScanner) (*Delivery, error) {
+	delivery := &Delivery{}
+	err := scanner.Scan(
+		&delivery.ID,
+		&delivery.ChatID,
+		&delivery.Message,
+		&delivery.DeliverAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func scanRows(rows *sql.Rows) ([]*Delivery, error) {
+	deliveries := make([]*Delivery, 0)
+
+	for rows.Next() {
+		delivery, err := scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
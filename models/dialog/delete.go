@@ -0,0 +1,26 @@
+package dialog
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Delete ends the dialog of the chat, it succeeds when there is none
+func (m *Model) Delete(chatID int64) error {
+	_, err := m.getDeleteBuilder().Where(sq.Eq{"chat_id": chatID}).Exec()
+	return err
+}
+
+// DeleteStep ends the dialog of the chat when it is at the step, so a dialog
+// started in the meantime is kept
+func (m *Model) DeleteStep(chatID int64, step string) error {
+	_, err := m.getDeleteBuilder().Where(sq.Eq{"chat_id": chatID, "step": step}).Exec()
+	return err
+}
+
+// DeleteExpired deletes the dialogs expired at now
+func (m *Model) DeleteExpired(now time.Time) error {
+	_, err := m.getDeleteBuilder().Where(sq.LtOrEq{"expires_at": now}).Exec()
+	return err
+}
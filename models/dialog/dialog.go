@@ -0,0 +1,68 @@
+package dialog
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+var (
+	tableName = "dialogs"
+
+	fields = []string{
+		"chat_id",
+		"step",
+		"data",
+		"expires_at",
+	}
+
+	preparedFields = strings.Join(fields, ", ")
+)
+
+// Dialog holds the question a chat was asked and is expected to answer
+type Dialog struct {
+	ChatID int64
+	// Step is the question the next message of the chat answers
+	Step string
+	// Data holds the answers of the previous steps of the dialog
+	Data map[string]string
+	// ExpiresAt is when the question is dropped if the chat did not answer
+	ExpiresAt time.Time
+}
+
+// Model holds the information for the model
+type Model struct {
+	db *sql.DB
+}
+
+// NewModel returns a new model
+func NewModel(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// getSelectBuilder returns a SELECT statement builder for the dialog model
+func (m *Model) getSelectBuilder() sq.SelectBuilder {
+	return sq.
+		Select(fields...).
+		PlaceholderFormat(sq.Dollar).
+		From(tableName).
+		RunWith(m.db)
+}
+
+// getInsertBuilder returns a INSERT statement builder for the dialog model
+func (m *Model) getInsertBuilder() sq.InsertBuilder {
+	return sq.
+		Insert(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
+
+// getDeleteBuilder returns a DELETE statement builder for the dialog model
+func (m *Model) getDeleteBuilder() sq.DeleteBuilder {
+	return sq.
+		Delete(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
@@ -0,0 +1,8 @@
+package dialog
+
+import "errors"
+
+var (
+	// ErrDialogNotFound is return when the chat has no pending dialog
+	ErrDialogNotFound = errors.New("dialog not found")
+)
@@ -0,0 +1,26 @@
+package dialog
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Find finds the dialog of the chat that did not expire at now
+func (m *Model) Find(chatID int64, now time.Time) (*Dialog, error) {
+	q := m.getSelectBuilder().
+		Where(sq.Eq{"chat_id": chatID}).
+		Where(sq.Gt{"expires_at": now}).
+		Limit(1)
+
+	dialog, err := scanRow(q)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDialogNotFound
+		}
+		return nil, err
+	}
+
+	return dialog, nil
+}
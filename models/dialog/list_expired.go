@@ -0,0 +1,26 @@
+package dialog
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListExpired lists the dialogs expired at now
+func (m *Model) ListExpired(now time.Time) ([]*Dialog, error) {
+	rows, err := m.getSelectBuilder().
+		Where(sq.LtOrEq{"expires_at": now}).
+		OrderBy("expires_at").
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dialogs, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return dialogs, nil
+}
@@ -0,0 +1,46 @@
+package dialog
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func scanRow(scanner sq.RowScanner) (*Dialog, error) {
+	var data []byte
+
+	dialog := &Dialog{}
+	err := scanner.Scan(
+		&dialog.ChatID,
+		&dialog.Step,
+		&data,
+		&dialog.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if data != nil {
+		err := json.Unmarshal(data, &dialog.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dialog, nil
+}
+
+func scanRows(rows *sql.Rows) ([]*Dialog, error) {
+	dialogs := make([]*Dialog, 0)
+
+	for rows.Next() {
+		dialog, err := scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		dialogs = append(dialogs, dialog)
+	}
+
+	return dialogs, rows.Err()
+}
@@ -0,0 +1,30 @@
+package dialog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Set saves the step the chat is at, replacing its pending dialog if any
+func (m *Model) Set(chatID int64, step string, data map[string]string, expiresAt time.Time) (*Dialog, error) {
+	var encoded *string
+	if len(data) != 0 {
+		tmp, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		encodedData := string(tmp)
+		encoded = &encodedData
+	}
+
+	row := m.getInsertBuilder().
+		Columns("chat_id", "step", "data", "expires_at").
+		Values(chatID, step, encoded, expiresAt).
+		Suffix(fmt.Sprintf(
+			"ON CONFLICT (chat_id) DO UPDATE SET step = EXCLUDED.step, data = EXCLUDED.data, expires_at = EXCLUDED.expires_at RETURNING %s",
+			preparedFields,
+		)).
+		QueryRow()
+	return scanRow(row)
+}
@@ -0,0 +1,21 @@
+package digest
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// Create adds a result to the next digest of the chat
+func (m *Model) Create(chatID int64, result *vaccines.Result, foundAt time.Time) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = m.getInsertBuilder().
+		Columns("chat_id", "result", "found_at").
+		Values(chatID, string(data), foundAt).
+		Exec()
+	return err
+}
@@ -0,0 +1,15 @@
+package digest
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// DeleteUntil deletes the entries of the chat up to the entry id once they are
+// sent, the ones added in the meantime are left for the next digest
+func (m *Model) DeleteUntil(chatID int64, id int64) error {
+	_, err := m.getDeleteBuilder().
+		Where(sq.Eq{"chat_id": chatID}).
+		Where(sq.LtOrEq{"id": id}).
+		Exec()
+	return err
+}
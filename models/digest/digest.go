@@ -0,0 +1,63 @@
+package digest
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+var (
+	tableName = "digest_entries"
+
+	fields = []string{
+		"id",
+		"chat_id",
+		"result",
+		"found_at",
+	}
+)
+
+// Entry holds a result waiting for the next digest of its chat
+type Entry struct {
+	ID      int64
+	ChatID  int64
+	Result  *vaccines.Result
+	FoundAt time.Time
+}
+
+// Model holds the information for the model
+type Model struct {
+	db *sql.DB
+}
+
+// NewModel returns a new model
+func NewModel(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// getSelectBuilder returns a SELECT statement builder for the digest model
+func (m *Model) getSelectBuilder() sq.SelectBuilder {
+	return sq.
+		Select(fields...).
+		PlaceholderFormat(sq.Dollar).
+		From(tableName).
+		RunWith(m.db)
+}
+
+// getInsertBuilder returns a INSERT statement builder for the digest model
+func (m *Model) getInsertBuilder() sq.InsertBuilder {
+	return sq.
+		Insert(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
+
+// getDeleteBuilder returns a DELETE statement builder for the digest model
+func (m *Model) getDeleteBuilder() sq.DeleteBuilder {
+	return sq.
+		Delete(tableName).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(m.db)
+}
@@ -0,0 +1,24 @@
+package digest
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// List lists the entries of the next digest of the chat, oldest first
+func (m *Model) List(chatID int64) ([]*Entry, error) {
+	rows, err := m.getSelectBuilder().
+		Where(sq.Eq{"chat_id": chatID}).
+		OrderBy("id").
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,43 @@
+package digest
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func scanRow(scanner sq.RowScanner) (*Entry, error) {
+	var result []byte
+
+	entry := &Entry{}
+	err := scanner.Scan(
+		&entry.ID,
+		&entry.ChatID,
+		&result,
+		&entry.FoundAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(result, &entry.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func scanRows(rows *sql.Rows) ([]*Entry, error) {
+	entries := make([]*Entry, 0)
+
+	for rows.Next() {
+		entry, err := scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
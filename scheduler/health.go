@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/sources"
+)
+
+// State is the state of the circuit breaker of a source
+type State string
+
+const (
+	// Closed means the source is healthy and fetched at its interval
+	Closed State = "closed"
+	// Open means the source failed too often and is not fetched until its cooldown ends
+	Open State = "open"
+	// HalfOpen means the cooldown ended and the next fetch decides if the source recovered
+	HalfOpen State = "half-open"
+)
+
+// ErrorKind classifies the error of a failed fetch
+type ErrorKind string
+
+const (
+	// ErrorTimeout is a fetch that reached its deadline
+	ErrorTimeout ErrorKind = "timeout"
+	// ErrorBanned is a source refusing our requests
+	ErrorBanned ErrorKind = "banned"
+	// ErrorHTTP is a source answering with an unexpected status
+	ErrorHTTP ErrorKind = "http"
+	// ErrorNetwork is a source that can't be reached
+	ErrorNetwork ErrorKind = "network"
+	// ErrorParse is a source answering something we can't read
+	ErrorParse ErrorKind = "parse"
+	// ErrorOther is every other error
+	ErrorOther ErrorKind = "other"
+)
+
+// Health holds the health of a source
+type Health struct {
+	Name                string
+	State               State
+	ConsecutiveFailures int
+	LastSuccess         time.Time
+	LastFailure         time.Time
+	LastErrorKind       ErrorKind
+	LastError           string
+	OpenUntil           time.Time
+
+	// opened counts the consecutive times the circuit opened, it doubles the cooldown
+	opened int
+}
+
+// recordSuccess closes the circuit of the source
+func (h *Health) recordSuccess(now time.Time) {
+	h.State = Closed
+	h.ConsecutiveFailures = 0
+	h.LastSuccess = now
+	h.OpenUntil = time.Time{}
+	h.opened = 0
+}
+
+// recordFailure counts the failure and opens the circuit once the source
+// failed too often, or right away when it banned us or was on probation.
+// It returns when the source should be fetched again.
+func (h *Health) recordFailure(now time.Time, err error, interval time.Duration, opts Options) time.Time {
+	h.ConsecutiveFailures++
+	h.LastFailure = now
+	h.LastErrorKind = classify(err)
+	h.LastError = err.Error()
+
+	if h.State != HalfOpen && h.LastErrorKind != ErrorBanned && h.ConsecutiveFailures < opts.FailureThreshold {
+		// back off exponentially until the circuit opens
+		return now.Add(capDuration(interval<<uint(h.ConsecutiveFailures-1), opts.MaxCooldown))
+	}
+
+	cooldown := opts.Cooldown
+	if h.LastErrorKind == ErrorBanned {
+		cooldown = opts.BannedCooldown
+	}
+	h.State = Open
+	h.OpenUntil = now.Add(capDuration(cooldown<<uint(h.opened), opts.MaxCooldown))
+	h.opened++
+	return h.OpenUntil
+}
+
+func capDuration(d, max time.Duration) time.Duration {
+	// a negative duration means the shift overflowed
+	if d <= 0 || d > max {
+		return max
+	}
+	return d
+}
+
+// classify returns the kind of the error of a failed fetch
+func classify(err error) ErrorKind {
+	var (
+		statusErr *sources.StatusError
+		netErr    net.Error
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTimeout
+	case errors.As(err, &statusErr):
+		if statusErr.Banned() {
+			return ErrorBanned
+		}
+		return ErrorHTTP
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorTimeout
+		}
+		return ErrorNetwork
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr), errors.Is(err, sources.ErrScrapeNoMatch):
+		return ErrorParse
+	}
+	return ErrorOther
+}
@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/sources"
+)
+
+var testOptions = Options{
+	FailureThreshold: 3,
+	Cooldown:         5 * time.Minute,
+	BannedCooldown:   30 * time.Minute,
+	MaxCooldown:      6 * time.Hour,
+}
+
+// timeoutError is a net.Error reaching its deadline
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRecordFailure(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	failure := errors.New("failure")
+	banned := &sources.StatusError{Code: 403, URL: "https://example.com"}
+
+	// step is a failure of the source, after its cooldown ended when halfOpen is set
+	type step struct {
+		err       error
+		halfOpen  bool
+		wantNext  time.Duration
+		wantState State
+	}
+	tests := []struct {
+		name     string
+		interval time.Duration
+		steps    []step
+	}{
+		{
+			name:     "backs off until the circuit opens",
+			interval: time.Minute,
+			steps: []step{
+				{err: failure, wantNext: time.Minute, wantState: Closed},
+				{err: failure, wantNext: 2 * time.Minute, wantState: Closed},
+				{err: failure, wantNext: 5 * time.Minute, wantState: Open},
+			},
+		},
+		{
+			name:     "doubles the cooldown when the probation fails",
+			interval: time.Minute,
+			steps: []step{
+				{err: failure, wantNext: time.Minute, wantState: Closed},
+				{err: failure, wantNext: 2 * time.Minute, wantState: Closed},
+				{err: failure, wantNext: 5 * time.Minute, wantState: Open},
+				{err: failure, halfOpen: true, wantNext: 10 * time.Minute, wantState: Open},
+				{err: failure, halfOpen: true, wantNext: 20 * time.Minute, wantState: Open},
+			},
+		},
+		{
+			name:     "opens right away when banned",
+			interval: time.Minute,
+			steps: []step{
+				{err: fmt.Errorf("fetch: %w", banned), wantNext: 30 * time.Minute, wantState: Open},
+			},
+		},
+		{
+			name:     "caps the back-off",
+			interval: 4 * time.Hour,
+			steps: []step{
+				{err: failure, wantNext: 4 * time.Hour, wantState: Closed},
+				{err: failure, wantNext: 6 * time.Hour, wantState: Closed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Health{State: Closed}
+			for i, s := range tt.steps {
+				if s.halfOpen {
+					h.State = HalfOpen
+				}
+				next := h.recordFailure(start, s.err, tt.interval, testOptions)
+				if got := next.Sub(start); got != s.wantNext {
+					t.Errorf("step %d: next fetch in %s, want %s", i, got, s.wantNext)
+				}
+				if h.State != s.wantState {
+					t.Errorf("step %d: state %s, want %s", i, h.State, s.wantState)
+				}
+				if h.ConsecutiveFailures != i+1 {
+					t.Errorf("step %d: %d consecutive failures, want %d", i, h.ConsecutiveFailures, i+1)
+				}
+			}
+
+			h.recordSuccess(start)
+			if h.State != Closed || h.ConsecutiveFailures != 0 || h.opened != 0 {
+				t.Errorf("recordSuccess() left state %s with %d failures and %d openings", h.State, h.ConsecutiveFailures, h.opened)
+			}
+		})
+	}
+}
+
+func TestCapDuration(t *testing.T) {
+	// the back-off shifts the interval by the number of failures
+	shift := 62
+	tests := []struct {
+		name string
+		d    time.Duration
+		want time.Duration
+	}{
+		{name: "below the maximum", d: time.Hour, want: time.Hour},
+		{name: "at the maximum", d: 6 * time.Hour, want: 6 * time.Hour},
+		{name: "above the maximum", d: 7 * time.Hour, want: 6 * time.Hour},
+		{name: "overflowed shift", d: time.Minute << uint(shift), want: 6 * time.Hour},
+		{name: "zero", d: 0, want: 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := capDuration(tt.d, 6*time.Hour); got != tt.want {
+				t.Errorf("capDuration(%s) = %s, want %s", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	var syntaxErr error
+	if err := json.Unmarshal([]byte("<html>"), &struct{}{}); err != nil {
+		syntaxErr = err
+	}
+	var typeErr error
+	if err := json.Unmarshal([]byte(`{"total": "many"}`), &struct{ Total int64 }{}); err != nil {
+		typeErr = err
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{name: "deadline", err: fmt.Errorf("fetch: %w", context.DeadlineExceeded), want: ErrorTimeout},
+		{name: "network timeout", err: &net.OpError{Op: "dial", Err: timeoutError{}}, want: ErrorTimeout},
+		{name: "forbidden", err: &sources.StatusError{Code: 403}, want: ErrorBanned},
+		{name: "too many requests", err: &sources.StatusError{Code: 429}, want: ErrorBanned},
+		{name: "server error", err: &sources.StatusError{Code: 500}, want: ErrorHTTP},
+		{name: "unreachable", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: ErrorNetwork},
+		{name: "invalid json", err: syntaxErr, want: ErrorParse},
+		{name: "unexpected json", err: typeErr, want: ErrorParse},
+		{name: "page layout", err: fmt.Errorf("scrape: %w", sources.ErrScrapeNoMatch), want: ErrorParse},
+		{name: "other", err: errors.New("failure"), want: ErrorOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify(tt.err); got != tt.want {
+				t.Errorf("classify(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/sources"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// tick is how often the scheduler looks for the sources that are due
+const tick = time.Second
+
+// Options holds the settings of the scheduler
+type Options struct {
+	Concurrency      int           `mapstructure:"concurrency"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	Cooldown         time.Duration `mapstructure:"cooldown"`
+	BannedCooldown   time.Duration `mapstructure:"banned_cooldown"`
+	MaxCooldown      time.Duration `mapstructure:"max_cooldown"`
+	// Batch is how long the outcomes are gathered before being handed to the
+	// handler together
+	Batch time.Duration `mapstructure:"batch"`
+}
+
+// setDefaults fills the options that are not configured
+func (o *Options) setDefaults() {
+	if o.Concurrency < 1 {
+		o.Concurrency = 8
+	}
+	if o.FailureThreshold < 1 {
+		o.FailureThreshold = 3
+	}
+	if o.Cooldown == 0 {
+		o.Cooldown = 5 * time.Minute
+	}
+	if o.BannedCooldown == 0 {
+		o.BannedCooldown = 30 * time.Minute
+	}
+	if o.MaxCooldown == 0 {
+		o.MaxCooldown = 6 * time.Hour
+	}
+	if o.Batch == 0 {
+		o.Batch = 5 * time.Second
+	}
+}
+
+// Outcome holds the result of fetching a source
+type Outcome struct {
+	Source  *sources.Source
+	Results []*vaccines.Result
+	Err     error
+}
+
+// Handler is called with the outcomes of the sources fetched within the same
+// batch window
+type Handler func(ctx context.Context, outcomes []*Outcome)
+
+// Scheduler fetches every source at its own interval, never running a source
+// twice at the same time and never running more than its concurrency of
+// fetches at once. A source failing too often is left alone until its
+// cooldown ends. The outcomes are handed to the handler in batches gathering
+// the fetches that ended within the same window.
+type Scheduler struct {
+	handler Handler
+	options Options
+	sem     chan struct{}
+
+	mu      sync.Mutex
+	entries []*entry
+}
+
+type entry struct {
+	source  *sources.Source
+	next    time.Time
+	running bool
+	health  Health
+}
+
+// New returns a new Scheduler
+func New(srcs []*sources.Source, options Options, handler Handler) *Scheduler {
+	options.setDefaults()
+	now := time.Now()
+	entries := make([]*entry, 0, len(srcs))
+	for _, source := range srcs {
+		// spread the first run of the sources over their jitter
+		entries = append(entries, &entry{
+			source: source,
+			next:   now.Add(jitter(source.Settings.Jitter)),
+			health: Health{Name: source.Name(), State: Closed},
+		})
+	}
+	return &Scheduler{
+		handler: handler,
+		options: options,
+		sem:     make(chan struct{}, options.Concurrency),
+		entries: entries,
+	}
+}
+
+// Run fetches the sources until the context is canceled, it returns once
+// every running fetch and handler is done
+func (s *Scheduler) Run(ctx context.Context) {
+	outcomes := make(chan *Outcome)
+	batched := make(chan struct{})
+	go func() {
+		defer close(batched)
+		s.batch(ctx, outcomes)
+	}()
+
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(outcomes)
+		<-batched
+	}()
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		for _, e := range s.due(time.Now()) {
+			e := e
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.run(ctx, e, outcomes)
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// due returns the entries that should run now and marks them as running
+func (s *Scheduler) due(now time.Time) []*entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*entry
+	for _, e := range s.entries {
+		if e.running || now.Before(e.next) {
+			continue
+		}
+		if e.health.State == Open {
+			e.health.State = HalfOpen
+			log.Infof("%s: cooldown is over, trying again", e.source.Name())
+		}
+		e.running = true
+		due = append(due, e)
+	}
+	return due
+}
+
+// run fetches the entry, records its outcome and sends it to be batched
+func (s *Scheduler) run(ctx context.Context, e *entry, outcomes chan<- *Outcome) {
+	outcome := s.fetch(ctx, e)
+
+	s.mu.Lock()
+	e.running = false
+	if ctx.Err() == nil {
+		s.record(time.Now(), e, outcome.Err)
+	}
+	s.mu.Unlock()
+
+	select {
+	case outcomes <- outcome:
+	case <-ctx.Done():
+	}
+}
+
+// batch hands the outcomes to the handler, a batch gathers the outcomes
+// received within the batch window following its first one so the results
+// of sources due at different times are still sent together. The batches are
+// handled while the next one is gathered, batch returns once the outcomes are
+// closed and every handler is done.
+func (s *Scheduler) batch(ctx context.Context, outcomes <-chan *Outcome) {
+	var (
+		wg      sync.WaitGroup
+		pending []*Outcome
+		window  <-chan time.Time
+	)
+	defer wg.Wait()
+
+	for {
+		select {
+		case outcome, ok := <-outcomes:
+			if !ok {
+				return
+			}
+			if len(pending) == 0 {
+				window = time.After(s.options.Batch)
+			}
+			pending = append(pending, outcome)
+		case <-window:
+			if ctx.Err() == nil {
+				handled := pending
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s.handler(ctx, handled)
+				}()
+			}
+			pending, window = nil, nil
+		}
+	}
+}
+
+// record updates the health of the entry with the outcome of its fetch and
+// schedules its next run
+func (s *Scheduler) record(now time.Time, e *entry, err error) {
+	name := e.source.Name()
+	settings := e.source.Settings
+
+	if err == nil {
+		if e.health.State != Closed {
+			log.Infof("%s: recovered after %d failures", name, e.health.ConsecutiveFailures)
+		}
+		e.health.recordSuccess(now)
+		e.next = now.Add(settings.Interval + jitter(settings.Jitter))
+		return
+	}
+
+	previous := e.health.State
+	e.next = e.health.recordFailure(now, err, settings.Interval, s.options)
+	kind := e.health.LastErrorKind
+	switch {
+	case e.health.State == Open && previous == Closed:
+		// only the opening of the circuit is an error, so a source failing
+		// for hours reports once instead of at every fetch
+		log.Errorf("%s: circuit opened until %s after %d failures (%s): %v", name, e.next.Format(time.RFC3339), e.health.ConsecutiveFailures, kind, err)
+	case e.health.State == Open:
+		log.Warnf("%s: still failing, circuit opened until %s (%s): %v", name, e.next.Format(time.RFC3339), kind, err)
+	default:
+		log.Warnf("%s: fetch failed %d times in a row, retrying at %s (%s): %v", name, e.health.ConsecutiveFailures, e.next.Format(time.RFC3339), kind, err)
+	}
+}
+
+// Health returns the health of every source
+func (s *Scheduler) Health() []Health {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := make([]Health, 0, len(s.entries))
+	for _, e := range s.entries {
+		health = append(health, e.health)
+	}
+	return health
+}
+
+// fetch fetches a source once a concurrency slot is free
+func (s *Scheduler) fetch(ctx context.Context, e *entry) *Outcome {
+	outcome := &Outcome{Source: e.source}
+
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		outcome.Err = ctx.Err()
+		return outcome
+	}
+	defer func() { <-s.sem }()
+
+	log.Infof("%s: Starting fetch", e.source.Name())
+	outcome.Results, outcome.Err = e.source.Fetch(ctx)
+	return outcome
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/sources"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// fakeFetcher is a source calling fetch when fetched
+type fakeFetcher struct {
+	name  string
+	fetch func(ctx context.Context) ([]*vaccines.Result, error)
+}
+
+func (f *fakeFetcher) Name() string { return f.name }
+
+func (f *fakeFetcher) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	return f.fetch(ctx)
+}
+
+// newSource returns a source fetched every interval without jitter
+func newSource(name string, interval time.Duration, fetch func(ctx context.Context) ([]*vaccines.Result, error)) *sources.Source {
+	return &sources.Source{
+		Fetcher:  &fakeFetcher{name: name, fetch: fetch},
+		Settings: sources.Settings{Timeout: time.Minute, Interval: interval},
+	}
+}
+
+func succeed(ctx context.Context) ([]*vaccines.Result, error) {
+	return []*vaccines.Result{{Vaccine: vaccines.Pfizer}}, nil
+}
+
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		entry     entry
+		wantDue   bool
+		wantState State
+	}{
+		{name: "due", entry: entry{next: now, health: Health{State: Closed}}, wantDue: true, wantState: Closed},
+		{name: "not due yet", entry: entry{next: now.Add(time.Second), health: Health{State: Closed}}, wantDue: false, wantState: Closed},
+		{name: "still running", entry: entry{next: now.Add(-time.Minute), running: true, health: Health{State: Closed}}, wantDue: false, wantState: Closed},
+		{name: "cooldown over", entry: entry{next: now, health: Health{State: Open}}, wantDue: true, wantState: HalfOpen},
+		{name: "cooling down", entry: entry{next: now.Add(time.Minute), health: Health{State: Open}}, wantDue: false, wantState: Open},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.entry
+			e.source = newSource(tt.name, time.Minute, succeed)
+			s := &Scheduler{entries: []*entry{&e}}
+
+			due := s.due(now)
+			if got := len(due) == 1; got != tt.wantDue {
+				t.Errorf("due() returned %d entries, want due %v", len(due), tt.wantDue)
+			}
+			if tt.wantDue && !e.running {
+				t.Error("due entry is not marked as running")
+			}
+			if e.health.State != tt.wantState {
+				t.Errorf("state %s, want %s", e.health.State, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestRunSchedulesNextFetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		fetch    func(ctx context.Context) ([]*vaccines.Result, error)
+		interval time.Duration
+		jitter   time.Duration
+		wantMin  time.Duration
+		wantMax  time.Duration
+	}{
+		{name: "interval", fetch: succeed, interval: time.Minute, wantMin: time.Minute, wantMax: time.Minute},
+		{name: "interval and jitter", fetch: succeed, interval: time.Minute, jitter: 10 * time.Second, wantMin: time.Minute, wantMax: time.Minute + 10*time.Second},
+		{
+			name:     "back-off",
+			fetch:    func(ctx context.Context) ([]*vaccines.Result, error) { return nil, errors.New("failure") },
+			interval: time.Minute,
+			jitter:   10 * time.Second,
+			wantMin:  time.Minute,
+			wantMax:  time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newSource(tt.name, tt.interval, tt.fetch)
+			source.Settings.Jitter = tt.jitter
+			s := New([]*sources.Source{source}, Options{}, nil)
+			e := s.entries[0]
+			e.running = true
+
+			outcomes := make(chan *Outcome, 1)
+			start := time.Now()
+			s.run(context.Background(), e, outcomes)
+			end := time.Now()
+
+			outcome := <-outcomes
+			if outcome.Source != source {
+				t.Error("outcome of another source")
+			}
+			if e.running {
+				t.Error("entry still marked as running")
+			}
+			if e.next.Before(start.Add(tt.wantMin)) || e.next.After(end.Add(tt.wantMax)) {
+				t.Errorf("next fetch in %s, want between %s and %s", e.next.Sub(start), tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	const concurrency = 2
+
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+	)
+	fetch := func(ctx context.Context) ([]*vaccines.Result, error) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil, nil
+	}
+
+	var srcs []*sources.Source
+	for i := 0; i < 6; i++ {
+		srcs = append(srcs, newSource(fmt.Sprintf("source %d", i), time.Minute, fetch))
+	}
+	s := New(srcs, Options{Concurrency: concurrency}, nil)
+
+	outcomes := make(chan *Outcome, len(srcs))
+	var wg sync.WaitGroup
+	for _, e := range s.due(time.Now()) {
+		e := e
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.run(context.Background(), e, outcomes)
+		}()
+	}
+	wg.Wait()
+
+	if len(outcomes) != len(srcs) {
+		t.Errorf("%d outcomes, want %d", len(outcomes), len(srcs))
+	}
+	if peak > concurrency {
+		t.Errorf("%d fetches ran at once, want at most %d", peak, concurrency)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	handled := make(chan []*Outcome, 2)
+	s := New(nil, Options{Batch: 50 * time.Millisecond}, func(ctx context.Context, outcomes []*Outcome) {
+		handled <- outcomes
+	})
+
+	outcomes := make(chan *Outcome)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.batch(context.Background(), outcomes)
+	}()
+
+	for i := 0; i < 3; i++ {
+		outcomes <- &Outcome{}
+	}
+	if batch := <-handled; len(batch) != 3 {
+		t.Errorf("first batch has %d outcomes, want 3", len(batch))
+	}
+	outcomes <- &Outcome{}
+	if batch := <-handled; len(batch) != 1 {
+		t.Errorf("second batch has %d outcomes, want 1", len(batch))
+	}
+
+	close(outcomes)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("batch did not return once the outcomes were closed")
+	}
+}
+
+func TestRunStopsWithTheContext(t *testing.T) {
+	// the sources block until the context is canceled, like a slow source
+	block := func(ctx context.Context) ([]*vaccines.Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	var handled int32
+	s := New(
+		[]*sources.Source{newSource("first", time.Minute, block), newSource("second", time.Minute, block)},
+		Options{Concurrency: 1},
+		func(ctx context.Context, outcomes []*Outcome) { atomic.StoreInt32(&handled, 1) },
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return once the context was canceled")
+	}
+	if atomic.LoadInt32(&handled) != 0 {
+		t.Error("the outcomes of a canceled run were handled")
+	}
+	for _, health := range s.Health() {
+		if health.ConsecutiveFailures != 0 {
+			t.Errorf("%s: the cancellation counted as a failure", health.Name)
+		}
+	}
+}
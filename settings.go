@@ -0,0 +1,645 @@
+package main
+
+import (
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
+	"github.com/eleboucher/berlin-vaccine-alert/models/chat"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// the pages of the settings message
+const (
+	pageMenu     = "menu"
+	pageVaccines = "vaccines"
+	pageDoses    = "doses"
+	pageWhere    = "where"
+	pageWhen     = "when"
+	pageTimes    = "times"
+	pageAmount   = "amount"
+	pageQuiet    = "quiet"
+	pageDelivery = "delivery"
+	pageSources  = "sources"
+	pageLanguage = "language"
+)
+
+// the kinds of the callback data of the settings buttons, the data is the kind
+// followed by a colon and the value of the option
+const (
+	pageCallback         = "page"
+	filterCallback       = "filter"
+	onlyFilterCallback   = "only"
+	doseCallback         = "dose"
+	distanceCallback     = "distance"
+	daysCallback         = "days"
+	slotsCallback        = "slots"
+	timeUnknownCallback  = "time-unknown"
+	amountCallback       = "amount"
+	uncountedCallback    = "uncounted"
+	quietHoursCallback   = "quiet"
+	quietModeCallback    = "quiet-mode"
+	digestCallback       = "digest"
+	muteCallback         = "mute"
+	subscribeCallback    = "subscribe"
+	resetSourcesCallback = "sources"
+	askCallback          = "ask"
+	languageCallback     = "language"
+)
+
+const (
+	// settingsMenuColumns is the number of buttons per row of the menu
+	settingsMenuColumns = 2
+	// checkMark prefixes the options chosen by the chat
+	checkMark = "✅ "
+	// notModifiedError is the error telegram answers when an edit does not
+	// change the message, like when an option is tapped twice
+	notModifiedError = "message is not modified"
+)
+
+// menuButtons are the buttons of the settings menu leading to the other
+// pages, the labels of the buttons and the texts of the pages are in English
+// and translated when rendered
+var menuButtons = []struct {
+	label string
+	page  string
+}{
+	{"💉 Vaccines", pageVaccines},
+	{"🔢 Doses", pageDoses},
+	{"📍 Where", pageWhere},
+	{"🗓 When", pageWhen},
+	{"🕐 Times of the week", pageTimes},
+	{"👥 Minimum of slots", pageAmount},
+	{"🌙 Quiet hours", pageQuiet},
+	{"📋 Instant alerts or digests", pageDelivery},
+	{"🏥 Sources", pageSources},
+	{"🌐 Language", pageLanguage},
+}
+
+// pagePrompts explain what each page of the settings is about
+var pagePrompts = map[string]string{
+	pageVaccines: "Tap the vaccines you look for, or Only to look for one of them alone. Every vaccine is sent when none is checked. You can also send /filter add, remove or list",
+	pageDoses:    "Tap the doses you look for, every dose is sent when none is checked",
+	pageWhere:    "Share your location with the keyboard button or send your postcode, then choose how far you can go. You can also look for districts with /district",
+	pageWhen:     "Choose how many days ahead you look for appointments, or send /days followed by a number",
+	pageTimes:    "Tap when you can go to an appointment, or send /slots followed by days and hours like \"mon-fri 17:00-24:00\"",
+	pageAmount:   "Choose how many free slots an alert needs before you receive it, or send /min followed by a number and an optional vaccine like \"5 pfizer\". Some sources can't count their slots, choose whether you receive their alerts",
+	pageQuiet:    "Choose when you do not want to be disturbed and what happens to the alerts then, or send /quiet followed by hours like \"22:00-07:00\". The hours are in your timezone, Europe/Berlin unless you send /timezone followed by another one",
+	pageDelivery: "Choose to receive the alerts instantly or in a digest, or send /digest followed by a duration like \"90m\"",
+	pageSources:  "Tap a source to mute or unmute it. Star sources to only receive their alerts, every source is sent when none is starred",
+	pageLanguage: "Choose the language I speak with you, or send /language followed by its code",
+}
+
+// filterOptions are the filters of the vaccines page, which are either a
+// vaccine or a family of vaccines
+var filterOptions = []string{
+	string(vaccines.AstraZeneca),
+	string(vaccines.JohnsonAndJohnson),
+	string(vaccines.Pfizer),
+	string(vaccines.Moderna),
+	string(vaccines.Novavax),
+	string(vaccines.MRNA),
+}
+
+// doseOptions are the doses of the doses page
+var doseOptions = []struct {
+	label string
+	dose  vaccines.Dose
+}{
+	{"First doses", vaccines.DoseFirst},
+	{"Second doses", vaccines.DoseSecond},
+	{"Boosters", vaccines.DoseBooster},
+}
+
+// distanceOptions are the maximum distances of the where page in kilometers
+var distanceOptions = []float64{2, 5, 10}
+
+// daysOptions are how many days ahead the when page looks for slots
+var daysOptions = []struct {
+	label string
+	days  int
+}{
+	{"Within 3 days", 3},
+	{"Within 1 week", 7},
+	{"Within 2 weeks", 14},
+	{"Within 6 weeks", 42},
+	{"Any date", 0},
+}
+
+// slotsOptions are the slot windows of the times page
+var slotsOptions = []struct {
+	label  string
+	window string
+}{
+	{"Weekday evenings (after 17:00)", "mon-fri 17:00-24:00"},
+	{"Weekends", "sat-sun"},
+}
+
+// amountOptions are the minimums of free slots of the amount page
+var amountOptions = []struct {
+	label  string
+	amount int
+}{
+	{"Any amount of slots", 0},
+	{"At least 3 slots", 3},
+	{"At least 10 slots", 10},
+}
+
+// quietHoursOptions are the quiet hours of the quiet page, off removes them
+var quietHoursOptions = []struct {
+	label string
+	hours string
+}{
+	{"Quiet from 22:00 to 07:00", "22:00-07:00"},
+	{"Quiet from 23:00 to 09:00", "23:00-09:00"},
+	{"No quiet hours", "off"},
+}
+
+// quietModeOptions are the quiet modes of the quiet page
+var quietModeOptions = []struct {
+	label string
+	mode  chat.QuietMode
+}{
+	{"Drop the alerts during quiet hours", chat.QuietDrop},
+	{"Send the alerts silently during quiet hours", chat.QuietSilent},
+	{"Send the alerts after quiet hours", chat.QuietQueue},
+}
+
+// digestOptions are the times between two digests of the delivery page
+var digestOptions = []struct {
+	label    string
+	interval time.Duration
+}{
+	{"Instant alerts", 0},
+	{"Digest every 30 minutes", 30 * time.Minute},
+	{"Digest every 2 hours", 2 * time.Hour},
+	{"Digest every day", 24 * time.Hour},
+}
+
+// sendSettings sends the settings message of the chat opened on the page
+func (t *Telegram) sendSettings(chatID int64, page string) error {
+	subscriber, err := t.chatModel.Find(chatID)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatID, settingsText(subscriber, page))
+	msg.ReplyMarkup = t.settingsKeyboard(subscriber, page)
+	_, err = t.bot.Send(msg)
+	return err
+}
+
+// handleCallback handles the buttons of the settings message, it applies the
+// option of the button and edits the message to show the new settings. The
+// query is always answered so the button stops loading, with an error text
+// when the option could not be applied.
+func (t *Telegram) handleCallback(query *tgbotapi.CallbackQuery) (err error) {
+	language := locales.English
+	if query.From != nil {
+		language = locales.FromCode(query.From.LanguageCode)
+	}
+	defer func() {
+		text := ""
+		switch {
+		case errors.Is(err, chat.ErrChatNotFound):
+			text, err = language.Translate(startFirstMessage), nil
+		case err != nil:
+			text = language.Translate(callbackFailedMessage)
+		}
+		_, answerErr := t.bot.Request(tgbotapi.NewCallback(query.ID, text))
+		if err == nil {
+			err = answerErr
+		}
+	}()
+
+	if query.Message == nil {
+		return nil
+	}
+	chatID := query.Message.Chat.ID
+	// saves the language of the chats that have none yet
+	language = t.language(chatID, query.From)
+
+	kind, value := query.Data, ""
+	if i := strings.Index(query.Data, ":"); i >= 0 {
+		kind, value = query.Data[:i], query.Data[i+1:]
+	}
+	subscriber, page, err := t.applySetting(chatID, kind, value)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, query.Message.MessageID, settingsText(subscriber, page), t.settingsKeyboard(subscriber, page))
+	edit.DisableWebPagePreview = true
+	_, err = t.bot.Request(edit)
+	if err != nil && !strings.Contains(err.Error(), notModifiedError) {
+		return err
+	}
+	return nil
+}
+
+// applySetting applies the option of a settings button to the chat, it returns
+// the updated chat and the page to show. The options that can't be applied
+// anymore, like the ones of a source that was removed, just show the page again.
+func (t *Telegram) applySetting(chatID int64, kind string, value string) (*chat.Chat, string, error) {
+	subscriber, err := t.chatModel.Find(chatID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch kind {
+	case askCallback:
+		step, ok := dialogSteps[value]
+		if !ok {
+			return subscriber, pageMenu, nil
+		}
+		return subscriber, step.page, t.askDialog(chatID, subscriber.Language, value, nil)
+	case pageCallback:
+		if _, ok := pagePrompts[value]; ok {
+			return subscriber, value, nil
+		}
+		return subscriber, pageMenu, nil
+	case filterCallback:
+		switch {
+		case value == "":
+			subscriber, err = t.chatModel.ReplaceFilters(chatID, nil)
+		case isChosenFilter(subscriber, value):
+			subscriber, err = t.chatModel.RemoveFilter(chatID, value)
+		default:
+			subscriber, err = t.chatModel.AddFilter(chatID, value)
+		}
+		return subscriber, pageVaccines, err
+	case onlyFilterCallback:
+		subscriber, err = t.chatModel.ReplaceFilters(chatID, []string{value})
+		return subscriber, pageVaccines, err
+	case doseCallback:
+		dose := vaccines.Dose(value)
+		if isChosenDose(subscriber, dose) {
+			subscriber, err = t.chatModel.RemoveDose(chatID, dose)
+		} else {
+			subscriber, err = t.chatModel.UpdateDoses(chatID, dose)
+		}
+		return subscriber, pageDoses, err
+	case distanceCallback:
+		distance, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			return subscriber, pageWhere, nil
+		}
+		if distance == 0 {
+			_, err = t.chatModel.UpdateDistricts(chatID, "")
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		subscriber, err = t.chatModel.UpdateMaxDistance(chatID, distance)
+		return subscriber, pageWhere, err
+	case daysCallback:
+		days, parseErr := strconv.Atoi(value)
+		if parseErr != nil {
+			return subscriber, pageWhen, nil
+		}
+		subscriber, err = t.chatModel.UpdateDays(chatID, days)
+		return subscriber, pageWhen, err
+	case slotsCallback:
+		if value == "" {
+			subscriber, err = t.chatModel.UpdateSlotWindows(chatID, nil)
+			return subscriber, pageTimes, err
+		}
+		window, parseErr := chat.ParseSlotWindow(value)
+		if parseErr != nil {
+			return subscriber, pageTimes, nil
+		}
+		if isChosenSlotWindow(subscriber, window) {
+			subscriber, err = t.chatModel.RemoveSlotWindow(chatID, window)
+		} else {
+			subscriber, err = t.chatModel.UpdateSlotWindows(chatID, &window)
+		}
+		return subscriber, pageTimes, err
+	case timeUnknownCallback:
+		subscriber, err = t.chatModel.UpdateSkipTimeUnknown(chatID, !subscriber.SkipTimeUnknown)
+		return subscriber, pageTimes, err
+	case amountCallback:
+		amount, parseErr := strconv.Atoi(value)
+		if parseErr != nil {
+			return subscriber, pageAmount, nil
+		}
+		subscriber, err = t.chatModel.UpdateMinAmount(chatID, "", amount)
+		return subscriber, pageAmount, err
+	case uncountedCallback:
+		subscriber, err = t.chatModel.UpdateSkipUncounted(chatID, !subscriber.SkipUncounted)
+		return subscriber, pageAmount, err
+	case quietHoursCallback:
+		if value == "off" {
+			subscriber, err = t.chatModel.UpdateQuietHours(chatID, nil)
+			return subscriber, pageQuiet, err
+		}
+		quietHours, parseErr := chat.ParseQuietHours(value)
+		if parseErr != nil {
+			return subscriber, pageQuiet, nil
+		}
+		subscriber, err = t.chatModel.UpdateQuietHours(chatID, &quietHours)
+		return subscriber, pageQuiet, err
+	case quietModeCallback:
+		updated, err := t.chatModel.UpdateQuietMode(chatID, chat.QuietMode(value))
+		if err == chat.ErrInvalidQuietMode {
+			return subscriber, pageQuiet, nil
+		}
+		return updated, pageQuiet, err
+	case digestCallback:
+		interval, parseErr := time.ParseDuration(value)
+		if parseErr != nil {
+			return subscriber, pageDelivery, nil
+		}
+		subscriber, err = t.updateDigestInterval(chatID, subscriber.Language, interval)
+		return subscriber, pageDelivery, err
+	case languageCallback:
+		language, parseErr := locales.Parse(value)
+		if parseErr != nil {
+			return subscriber, pageLanguage, nil
+		}
+		subscriber, err = t.updateLanguage(chatID, language)
+		return subscriber, pageLanguage, err
+	case muteCallback:
+		name, ok := t.sourceName(value)
+		if !ok {
+			return subscriber, pageSources, nil
+		}
+		subscriber, err = t.chatModel.UpdateMutedSources(chatID, name, !hasName(subscriber.MutedSources, name))
+		return subscriber, pageSources, err
+	case subscribeCallback:
+		name, ok := t.sourceName(value)
+		if !ok {
+			return subscriber, pageSources, nil
+		}
+		subscriber, err = t.chatModel.UpdateSources(chatID, name, !hasName(subscriber.Sources, name))
+		return subscriber, pageSources, err
+	case resetSourcesCallback:
+		_, err = t.chatModel.UpdateSources(chatID, "", false)
+		if err != nil {
+			return nil, "", err
+		}
+		subscriber, err = t.chatModel.UpdateMutedSources(chatID, "", false)
+		return subscriber, pageSources, err
+	}
+	return subscriber, pageMenu, nil
+}
+
+// settingsText renders the text of the settings message on the page, the
+// prompt of the page followed by the current settings
+func settingsText(subscriber *chat.Chat, page string) string {
+	prompt, ok := pagePrompts[page]
+	if !ok {
+		return subscriber.Language.Translate("Tap a setting to change it") + "\n\n" + formatSettings(subscriber)
+	}
+
+	var current string
+	switch page {
+	case pageVaccines:
+		current = formatFilters(subscriber)
+	case pageDoses:
+		current = formatDoses(subscriber)
+	case pageWhere:
+		current = formatWhere(subscriber)
+	case pageWhen, pageTimes:
+		current = formatWhen(subscriber)
+	case pageAmount:
+		current = formatAmount(subscriber)
+	case pageQuiet:
+		current = formatQuiet(subscriber)
+	case pageDelivery:
+		current = formatDelivery(subscriber)
+	case pageSources:
+		current = formatSources(subscriber)
+	case pageLanguage:
+		current = formatLanguage(subscriber)
+	}
+	return subscriber.Language.Translate(prompt) + "\n\n" + current
+}
+
+// settingsKeyboard renders the buttons of the settings message on the page in
+// the language of the chat, the options chosen by the chat are checked
+func (t *Telegram) settingsKeyboard(subscriber *chat.Chat, page string) tgbotapi.InlineKeyboardMarkup {
+	language := subscriber.Language
+	var rows [][]tgbotapi.InlineKeyboardButton
+	option := func(label string, checked bool, kind string, value string) {
+		label = language.Translate(label)
+		if checked {
+			label = checkMark + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, kind+":"+value),
+		))
+	}
+	ask := func(label string, step string) {
+		option("✏️ "+language.Translate(label), false, askCallback, step)
+	}
+
+	switch page {
+	case pageVaccines:
+		for _, filter := range filterOptions {
+			label := language.Translate(vaccines.FilterName(filter))
+			if isChosenFilter(subscriber, filter) {
+				label = checkMark + label
+			}
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(label, filterCallback+":"+filter),
+				tgbotapi.NewInlineKeyboardButtonData(language.Translate("Only"), onlyFilterCallback+":"+filter),
+			))
+		}
+		option("Every vaccine", len(subscriber.Filters) == 0, filterCallback, "")
+	case pageDoses:
+		for _, o := range doseOptions {
+			option(o.label, isChosenDose(subscriber, o.dose), doseCallback, string(o.dose))
+		}
+		option("Every dose", len(subscriber.Doses) == 0, doseCallback, "")
+	case pageWhere:
+		for _, distance := range distanceOptions {
+			value := strconv.FormatFloat(distance, 'g', -1, 64)
+			option(language.Sprintf("Within %s km", value), subscriber.MaxDistance == distance, distanceCallback, value)
+		}
+		option("Anywhere in Berlin", subscriber.MaxDistance == 0 && len(subscriber.Districts) == 0, distanceCallback, "0")
+		ask("Send my postcode", stepPostcode)
+		ask("Add a district", stepDistrict)
+	case pageWhen:
+		for _, o := range daysOptions {
+			option(o.label, subscriber.Days == o.days, daysCallback, strconv.Itoa(o.days))
+		}
+		ask("Other number of days", stepDays)
+	case pageTimes:
+		for _, o := range slotsOptions {
+			window, _ := chat.ParseSlotWindow(o.window)
+			option(o.label, isChosenSlotWindow(subscriber, window), slotsCallback, o.window)
+		}
+		option("Any time", len(subscriber.SlotWindows) == 0, slotsCallback, "")
+		ask("Other times", stepSlots)
+		option("Skip appointments with unknown time", subscriber.SkipTimeUnknown, timeUnknownCallback, "")
+	case pageAmount:
+		for _, o := range amountOptions {
+			option(o.label, subscriber.MinAmount == o.amount, amountCallback, strconv.Itoa(o.amount))
+		}
+		option("Skip alerts with unknown amount", subscriber.SkipUncounted, uncountedCallback, "")
+		ask("Other minimum, or one per vaccine", stepMinAmount)
+	case pageQuiet:
+		for _, o := range quietHoursOptions {
+			checked := subscriber.QuietHours == nil
+			if quietHours, err := chat.ParseQuietHours(o.hours); err == nil {
+				checked = subscriber.QuietHours != nil && *subscriber.QuietHours == quietHours
+			}
+			option(o.label, checked, quietHoursCallback, o.hours)
+		}
+		ask("Other hours", stepQuiet)
+		for _, o := range quietModeOptions {
+			option(o.label, subscriber.QuietMode == o.mode, quietModeCallback, string(o.mode))
+		}
+		ask("Change my timezone", stepTimezone)
+	case pageDelivery:
+		for _, o := range digestOptions {
+			option(o.label, subscriber.DigestInterval == o.interval, digestCallback, o.interval.String())
+		}
+		ask("Other interval", stepDigest)
+	case pageSources:
+		for _, name := range t.sources {
+			mute := "🔔 " + name
+			if hasName(subscriber.MutedSources, name) {
+				mute = "🔇 " + name
+			}
+			star := "☆"
+			if hasName(subscriber.Sources, name) {
+				star = "⭐"
+			}
+			key := sourceKey(name)
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(mute, muteCallback+":"+key),
+				tgbotapi.NewInlineKeyboardButtonData(star, subscribeCallback+":"+key),
+			))
+		}
+		option("Every source", len(subscriber.Sources) == 0 && len(subscriber.MutedSources) == 0, resetSourcesCallback, "")
+	case pageLanguage:
+		for _, l := range locales.Languages() {
+			option(l.Name(), language == l || language == "" && l == locales.English, languageCallback, string(l))
+		}
+	default:
+		var row []tgbotapi.InlineKeyboardButton
+		for _, b := range menuButtons {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(language.Translate(b.label), pageCallback+":"+b.page))
+			if len(row) == settingsMenuColumns {
+				rows = append(rows, row)
+				row = nil
+			}
+		}
+		if len(row) != 0 {
+			rows = append(rows, row)
+		}
+		return tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ "+language.Translate(backButton), pageCallback+":"+pageMenu),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// isChosenFilter reports whether the chat looks for the filter
+func isChosenFilter(subscriber *chat.Chat, filter string) bool {
+	return hasName(subscriber.Filters, filter)
+}
+
+// isChosenDose reports whether the chat looks for the dose
+func isChosenDose(subscriber *chat.Chat, dose vaccines.Dose) bool {
+	for _, d := range subscriber.Doses {
+		if d == dose {
+			return true
+		}
+	}
+	return false
+}
+
+// isChosenSlotWindow reports whether the window is one of the slot windows of
+// the chat
+func isChosenSlotWindow(subscriber *chat.Chat, window chat.SlotWindow) bool {
+	for _, w := range subscriber.SlotWindows {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceKey identifies a source in the data of a button, which telegram limits
+// to 64 bytes while some names are longer
+func sourceKey(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return strconv.FormatUint(uint64(h.Sum32()), 36)
+}
+
+// sourceName returns the name of the configured source identified by the key
+func (t *Telegram) sourceName(key string) (string, bool) {
+	for _, name := range t.sources {
+		if sourceKey(name) == key {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// hasName reports whether the name is one of the names
+func hasName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSettings describes every setting of the chat
+func formatSettings(subscriber *chat.Chat) string {
+	return strings.Join([]string{
+		formatFilters(subscriber),
+		formatDoses(subscriber),
+		formatWhere(subscriber),
+		formatWhen(subscriber),
+		formatAmount(subscriber),
+		formatSources(subscriber),
+		formatQuiet(subscriber),
+		formatDelivery(subscriber),
+		formatLanguage(subscriber),
+	}, "\n\n")
+}
+
+// formatFilters describes the vaccines the chat looks for
+func formatFilters(subscriber *chat.Chat) string {
+	if len(subscriber.Filters) == 0 {
+		return subscriber.Language.Translate("you look for every vaccine")
+	}
+	names := make([]string, 0, len(subscriber.Filters))
+	for _, filter := range subscriber.Filters {
+		names = append(names, subscriber.Language.Translate(vaccines.FilterName(filter)))
+	}
+	return subscriber.Language.Sprintf("you look for %s", strings.Join(names, ", "))
+}
+
+// formatDoses describes the doses the chat looks for
+func formatDoses(subscriber *chat.Chat) string {
+	if len(subscriber.Doses) == 0 {
+		return subscriber.Language.Translate("you look for every dose")
+	}
+	names := make([]string, 0, len(subscriber.Doses))
+	for _, dose := range subscriber.Doses {
+		names = append(names, subscriber.Language.Translate(string(dose)+" doses"))
+	}
+	return subscriber.Language.Sprintf("you look for %s", strings.Join(names, ", "))
+}
+
+// formatLanguage describes the language the chat is talked to in
+func formatLanguage(subscriber *chat.Chat) string {
+	language := subscriber.Language
+	if language == "" {
+		language = locales.English
+	}
+	return language.Sprintf("I speak %s with you", language.Name())
+}
@@ -1,13 +1,12 @@
 package sources
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
-	"os"
-	"reflect"
-	"text/template"
+	"net/url"
 	"time"
 
 	"github.com/eleboucher/berlin-vaccine-alert/internals/proxy"
@@ -15,8 +14,6 @@ import (
 	"github.com/google/go-querystring/query"
 )
 
-const tDoctolib = "{{.Amount}} appointments for {{.VaccineName}} {{.Detail}} available {{.URL}}"
-
 type ResultDoctolib struct {
 	Availabilities []*Availability `json:"availabilities,omitempty"`
 	Total          int64           `json:"total"`
@@ -31,85 +28,71 @@ type Availability struct {
 // Doctolib holds the information for fetching the information for the
 // doctolib website
 type Doctolib struct {
-	VaccineName      string             `url:"-"`
-	URL              string             `url:"-"`
-	Detail           string             `url:"-"`
-	Delay            time.Duration      `url:"-"`
-	Limit            string             `url:"limit"`
-	PracticeID       string             `url:"pratice_ids"`
-	AgendaID         string             `url:"agenda_ids"`
-	VisitMotiveID    string             `url:"visit_motive_ids"`
-	StartDate        string             `url:"start_date"`
-	resultSendLastAt time.Time          `url:"-"`
-	lastResult       []*vaccines.Result `url:"-"`
-	Proxy            *proxy.Proxy       `url:"-"`
+	Base          `mapstructure:",squash" url:"-"`
+	Vaccine       vaccines.Vaccine `mapstructure:"vaccine_name" url:"-"`
+	Limit         string           `mapstructure:"-" url:"limit"`
+	PracticeID    string           `mapstructure:"practice_id" url:"pratice_ids"`
+	AgendaID      string           `mapstructure:"agenda_id" url:"agenda_ids"`
+	VisitMotiveID string           `mapstructure:"visit_motive_id" url:"visit_motive_ids"`
+	// VisitMotiveName is the name doctolib shows for the visit motive, the dose
+	// is classified from it when not set
+	VisitMotiveName string `mapstructure:"visit_motive_name" url:"-"`
+	// Days is how many days ahead the slots are looked for
+	Days      int          `mapstructure:"days" url:"-"`
+	StartDate string       `mapstructure:"-" url:"start_date"`
+	Proxy     *proxy.Proxy `mapstructure:"-" url:"-"`
+
+	// client sends the requests through clientProxy, it is rebuilt when the
+	// proxy is renewed
+	client      *http.Client
+	clientProxy string
 }
 
-// Name return the name of the source
-func (d *Doctolib) Name() string {
-	return "Doctolib " + d.URL
+// Validate checks the source has a vaccine and classifies its dose from the
+// name of its visit motive
+func (d *Doctolib) Validate() error {
+	if d.Vaccine == "" {
+		return ErrMissingVaccine
+	}
+	if d.Dose == vaccines.DoseUnknown {
+		d.Dose = vaccines.ClassifyDose(d.VisitMotiveName)
+	}
+	return nil
 }
 
 // Fetch fetches all the available appointment and filter then and return the results
-func (d *Doctolib) Fetch() ([]*vaccines.Result, error) {
-	url := "https://www.doctolib.de/availabilities.json"
-	var ret vaccines.Result
+func (d *Doctolib) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	ret := d.newResult(d.Vaccine)
+	var slots []time.Time
 	startDate := time.Now()
+	end := horizon(d.Days)
 	for {
-
-		os.Setenv("HTTP_PROXY", d.Proxy.Proxy())
-		os.Setenv("HTTPS_PROXY", d.Proxy.Proxy())
-		d.StartDate = startDate.Format("2006-01-02")
-		d.Limit = "1000"
-
-		v, err := query.Values(d)
-		if err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequest("GET", url+"?"+v.Encode(), nil)
-		req.Header.Add("authority", "www.doctolib.de")
-		req.Header.Add("accept", "application/json")
-		req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.114 Safari/537.36")
-		if err != nil {
-			return nil, err
-		}
-		res, err := http.DefaultClient.Do(req)
-		if err != nil {
-			d.Proxy.RenewProxy()
-			return nil, err
-		}
-		defer res.Body.Close()
-		body, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			d.Proxy.RenewProxy()
-			return nil, err
-		}
-		var resp ResultDoctolib
-		err = json.Unmarshal(body, &resp)
+		resp, err := d.fetchAvailabilities(ctx, startDate)
 		if err != nil {
 			return nil, err
 		}
 
-		ret.VaccineName = d.VaccineName
-
 		for _, availability := range resp.Availabilities {
 			date, err := time.Parse("2006-01-02", availability.Date)
 			if err != nil {
 				continue
 			}
-			// do not show vaccine that is in more than 30 days for now
-			if date.After(time.Now().Add(30 * 24 * time.Hour)) {
+			if date.After(end) {
 				continue
 			}
 			ret.Amount += int64(len(availability.Slots))
+			for _, slot := range availability.Slots {
+				if t, ok := parseDoctolibSlot(slot); ok {
+					slots = append(slots, t)
+				}
+			}
 		}
 		if resp.NextSlot != nil {
 			date, err := time.Parse("2006-01-02", *resp.NextSlot)
 			if err != nil {
 				break
 			}
-			// do not show vaccine that is in more than 30 days for now
-			if date.After(time.Now().Add(30 * 24 * time.Hour)) {
+			if date.After(end) {
 				break
 			}
 			startDate = date
@@ -120,55 +103,107 @@ func (d *Doctolib) Fetch() ([]*vaccines.Result, error) {
 	if ret.Amount == 0 {
 		return nil, nil
 	}
-	message, err := d.formatMessage(ret)
+	ret.SetSlots(slots)
+	return []*vaccines.Result{ret}, nil
+}
+
+// parseDoctolibSlot parses a slot of an availability, doctolib gives either
+// the start date of the slot or an object holding it
+func parseDoctolibSlot(slot interface{}) (time.Time, bool) {
+	var value string
+	switch s := slot.(type) {
+	case string:
+		value = s
+	case map[string]interface{}:
+		value, _ = s["start_date"].(string)
+	}
+	t, err := time.Parse(time.RFC3339, value)
 	if err != nil {
-		return nil, err
+		return time.Time{}, false
 	}
-	ret.Message = message
-	return []*vaccines.Result{&ret}, nil
+	return t, true
 }
 
-func (d *Doctolib) formatMessage(result vaccines.Result) (string, error) {
-	res := struct {
-		URL         string
-		VaccineName string
-		Amount      int64
-		Detail      string
-	}{
-		URL:         d.URL,
-		VaccineName: d.VaccineName,
-		Detail:      d.Detail,
-		Amount:      result.Amount,
+// fetchAvailabilities fetches one page of availabilities starting at startDate
+func (d *Doctolib) fetchAvailabilities(ctx context.Context, startDate time.Time) (*ResultDoctolib, error) {
+	endpoint := "https://www.doctolib.de/availabilities.json"
+
+	client, err := d.httpClient(ctx)
+	if err != nil {
+		return nil, err
 	}
+	d.StartDate = startDate.Format("2006-01-02")
+	d.Limit = "1000"
 
-	t, err := template.New("message").Parse(tDoctolib)
+	v, err := query.Values(d)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("authority", "www.doctolib.de")
+	req.Header.Add("accept", "application/json")
+	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.114 Safari/537.36")
+	res, err := client.Do(req)
+	if err != nil {
+		d.renewProxy(ctx, err)
+		return nil, err
+	}
+	defer res.Body.Close()
+	err = checkStatus(res)
+	if err != nil {
+		d.renewProxy(ctx, err)
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return "", err
+		d.renewProxy(ctx, err)
+		return nil, err
 	}
-	var tpl bytes.Buffer
-	err = t.Execute(&tpl, res)
+	var resp ResultDoctolib
+	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return tpl.String(), nil
+	return &resp, nil
 }
 
-// ShouldSendResult check if the result should be send now
-func (d *Doctolib) ShouldSendResult(result []*vaccines.Result) bool {
-	if d.Delay == 0 {
-		d.Delay = 1
-	}
-	if !reflect.DeepEqual(d.lastResult, result) && d.resultSendLastAt.Before(time.Now().Add(-d.Delay*1*time.Minute)) {
-		return true
+// renewProxy renews the proxy when it caused the error of the request, either
+// because doctolib banned it or because it can't be reached. The error of a
+// canceled or expired fetch says nothing about the proxy, which is kept.
+func (d *Doctolib) renewProxy(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
 	}
-	if d.resultSendLastAt.Before(time.Now().Add(d.Delay - 10*time.Minute)) {
-		return true
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && !statusErr.Banned() {
+		return
 	}
-	return false
+	d.Proxy.RenewProxy(ctx)
 }
 
-// ResultSentNow set that the appointment has been sent
-func (d *Doctolib) ResultSentNow(result []*vaccines.Result) {
-	d.resultSendLastAt = time.Now()
-	d.lastResult = result
+// httpClient returns the client sending the requests through the current
+// proxy, the requests go out directly when no proxy could be found
+func (d *Doctolib) httpClient(ctx context.Context) (*http.Client, error) {
+	address := d.Proxy.Proxy(ctx)
+	if d.client != nil && address == d.clientProxy {
+		return d.client, nil
+	}
+
+	transport := &http.Transport{}
+	if address != "" {
+		proxyURL, err := url.Parse(address)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if d.client != nil {
+		d.client.CloseIdleConnections()
+	}
+	d.client = &http.Client{Transport: transport, Timeout: client.Timeout}
+	d.clientProxy = address
+	return d.client, nil
 }
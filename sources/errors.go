@@ -0,0 +1,27 @@
+package sources
+
+import "errors"
+
+var (
+	// ErrUnknownKind is return when a source entry has a kind that is not registered
+	ErrUnknownKind = errors.New("unknown source kind")
+
+	// ErrMissingName is return when a source entry has no name
+	ErrMissingName = errors.New("source name is missing")
+
+	// ErrDuplicateName is return when two source entries share the same name
+	ErrDuplicateName = errors.New("source name is already used")
+
+	// ErrMissingVaccine is return when a source entry of a single vaccine has no vaccine_name
+	ErrMissingVaccine = errors.New("source vaccine_name is missing")
+
+	// ErrScrapeMissingTarget is return when a scrape source has no url or selector
+	ErrScrapeMissingTarget = errors.New("scrape source needs an url and a selector")
+
+	// ErrScrapeMissingGroup is return when the regex of a scrape source lacks a named group
+	ErrScrapeMissingGroup = errors.New("scrape regex is missing the named group")
+
+	// ErrScrapeNoMatch is return when the selector of a scrape source matches nothing,
+	// which usually means the layout of the page changed
+	ErrScrapeNoMatch = errors.New("scrape selector matched nothing, the page layout probably changed")
+)
@@ -0,0 +1,147 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/dedup"
+	"github.com/eleboucher/berlin-vaccine-alert/geo"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+const (
+	// defaultTimeout is the deadline of a fetch when the source does not configure one
+	defaultTimeout = 10 * time.Second
+	// defaultInterval is the time between two fetches when the source does not configure one
+	defaultInterval = 30 * time.Second
+	// defaultJitter is the random delay added to the interval when the source does not configure one
+	defaultJitter = 5 * time.Second
+	// defaultDays is how many days ahead a source looks for slots when it does not configure it
+	defaultDays = 42
+)
+
+// Fetcher is the type to allow fetching information for an appointment
+type Fetcher interface {
+	Name() string
+	Fetch(ctx context.Context) ([]*vaccines.Result, error)
+}
+
+// Base holds the settings shared by every kind of source, mostly the
+// information about the practice it watches
+type Base struct {
+	SourceName string        `mapstructure:"name"`
+	Practice   string        `mapstructure:"practice"`
+	Address    string        `mapstructure:"address"`
+	District   string        `mapstructure:"district"`
+	Postcode   string        `mapstructure:"postcode"`
+	Latitude   float64       `mapstructure:"latitude"`
+	Longitude  float64       `mapstructure:"longitude"`
+	BookingURL string        `mapstructure:"booking_url"`
+	Phone      string        `mapstructure:"phone"`
+	Dose       vaccines.Dose `mapstructure:"dose"`
+	Detail     string        `mapstructure:"detail"`
+}
+
+// Name return the name of the source
+func (b *Base) Name() string {
+	return b.SourceName
+}
+
+// locate fills the district and the coordinates of the practice from its
+// postcode when they are not set, and checks the district is a Berlin one so
+// it matches the districts chosen by the chats
+func (b *Base) locate() error {
+	if b.Postcode != "" {
+		place, err := geo.Locate(b.Postcode)
+		if err != nil {
+			return fmt.Errorf("%w: %q", err, b.Postcode)
+		}
+		if b.District == "" {
+			b.District = place.District
+		}
+		if b.Latitude == 0 && b.Longitude == 0 {
+			b.Latitude = place.Latitude
+			b.Longitude = place.Longitude
+		}
+	}
+	if b.District != "" {
+		district, err := geo.ParseDistrict(b.District)
+		if err != nil {
+			return fmt.Errorf("%w: %q", err, b.District)
+		}
+		b.District = district
+	}
+	return nil
+}
+
+// newResult returns a result for the vaccine filled with the information of the practice
+func (b *Base) newResult(vaccine vaccines.Vaccine) *vaccines.Result {
+	return &vaccines.Result{
+		Practice:   b.Practice,
+		Address:    b.Address,
+		District:   b.District,
+		Latitude:   b.Latitude,
+		Longitude:  b.Longitude,
+		BookingURL: b.BookingURL,
+		Phone:      b.Phone,
+		Vaccine:    vaccine,
+		Dose:       b.Dose,
+		Detail:     b.Detail,
+	}
+}
+
+// horizon returns the last time a source looks for slots at, days ahead of now
+func horizon(days int) time.Time {
+	if days <= 0 {
+		days = defaultDays
+	}
+	return time.Now().AddDate(0, 0, days)
+}
+
+// Settings holds the settings of a source entry that are not about fetching
+type Settings struct {
+	Kind     string        `mapstructure:"kind"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Interval time.Duration `mapstructure:"interval"`
+	Jitter   time.Duration `mapstructure:"jitter"`
+	Dedup    dedup.Window  `mapstructure:"dedup"`
+}
+
+// Source is a configured Fetcher along with the settings of its entry
+type Source struct {
+	Fetcher
+	Settings Settings
+}
+
+// Fetch fetches the source, giving up once its timeout is reached, and stamps
+// the results with the name of the source and the time they were fetched
+func (s *Source) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.Settings.Timeout)
+	defer cancel()
+
+	results, err := s.Fetcher.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, result := range results {
+		result.Source = s.Name()
+		result.FetchedAt = now
+	}
+	return results, nil
+}
+
+// setDefaults fills the settings the entry does not configure
+func (s *Settings) setDefaults() {
+	if s.Timeout == 0 {
+		s.Timeout = defaultTimeout
+	}
+	if s.Interval == 0 {
+		s.Interval = defaultInterval
+	}
+	if s.Jitter == 0 {
+		s.Jitter = defaultJitter
+	}
+	s.Dedup.SetDefaults()
+}
@@ -1,11 +1,11 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
-	"reflect"
-	"time"
 
 	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
 )
@@ -55,31 +55,45 @@ type Purpose struct {
 // Helios holds the information for fetching the information for the
 // https://patienten.helios-gesundheit.de/ website
 type Helios struct {
-	resultSendLastAt time.Time
-	lastResult       []*vaccines.Result
+	Base            `mapstructure:",squash"`
+	ResourceID      int64            `mapstructure:"resource_id"`
+	SpecialtyUUID   string           `mapstructure:"specialty_uuid"`
+	InsuranceTypeID int64            `mapstructure:"insurance_type_id"`
+	Vaccine         vaccines.Vaccine `mapstructure:"vaccine_name"`
 }
 
-const tHelios = "appointments for biontech available call https://patienten.helios-gesundheit.de/appointments/book-appointment?facility=10&physician=21646&purpose=33239&resource=58"
-
-// Name return the name of the source
-func (h *Helios) Name() string {
-	return "Helios"
+// Validate defaults the vaccine of the source to BioNTech/Pfizer, the one the
+// Helios clinic offered when the source was added
+func (h *Helios) Validate() error {
+	if h.Vaccine == "" {
+		h.Vaccine = vaccines.Pfizer
+	}
+	return nil
 }
 
 // Fetch fetches all the available appointment and filter then and return the results
-func (h *Helios) Fetch() ([]*vaccines.Result, error) {
-	url := "https://api.patienten.helios-gesundheit.de/api/appointment/resources/21646/purposes?insuranceTypeId=1&specialtyUUID=c619bfb1-9e18-404d-b960-dfac6c072490"
+func (h *Helios) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	url := fmt.Sprintf(
+		"https://api.patienten.helios-gesundheit.de/api/appointment/resources/%d/purposes?insuranceTypeId=%d&specialtyUUID=%s",
+		h.ResourceID,
+		h.InsuranceTypeID,
+		h.SpecialtyUUID,
+	)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
+	err = checkStatus(res)
+	if err != nil {
+		return nil, err
+	}
 	body, err := ioutil.ReadAll(res.Body)
 
 	if err != nil {
@@ -91,27 +105,7 @@ func (h *Helios) Fetch() ([]*vaccines.Result, error) {
 		return nil, err
 	}
 	if len(resp.Purposes) > 0 && resp.Purposes[0].BookingPlanUUID != nil {
-		var ret vaccines.Result
-		ret.VaccineName = vaccines.Pfizer
-		ret.Message = tHelios
-		return []*vaccines.Result{&ret}, nil
+		return []*vaccines.Result{h.newResult(h.Vaccine)}, nil
 	}
 	return nil, nil
 }
-
-// ShouldSendResult check if the result should be send now
-func (h *Helios) ShouldSendResult(result []*vaccines.Result) bool {
-	if !reflect.DeepEqual(h.lastResult, result) && h.resultSendLastAt.Before(time.Now().Add(-1*time.Minute)) {
-		return true
-	}
-	if h.resultSendLastAt.Before(time.Now().Add(-10 * time.Minute)) {
-		return true
-	}
-	return false
-}
-
-// ResultSentNow set that the appointment has been sent
-func (h *Helios) ResultSentNow(result []*vaccines.Result) {
-	h.resultSendLastAt = time.Now()
-	h.lastResult = result
-}
@@ -0,0 +1,36 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+func TestHeliosVaccine(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   map[string]interface{}
+		want    vaccines.Vaccine
+		wantErr bool
+	}{
+		{name: "default", entry: map[string]interface{}{}, want: vaccines.Pfizer},
+		{name: "configured", entry: map[string]interface{}{"vaccine_name": "moderna"}, want: vaccines.Moderna},
+		{name: "alias", entry: map[string]interface{}{"vaccine_name": "Spikevax"}, want: vaccines.Moderna},
+		{name: "unknown", entry: map[string]interface{}{"vaccine_name": "Grippeimpfung"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Helios{}
+			err := decode(tt.entry, h)
+			if err == nil {
+				err = h.Validate()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && h.Vaccine != tt.want {
+				t.Errorf("vaccine %q, want %q", h.Vaccine, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package sources
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// client is the http client shared by the sources, the deadline of a fetch
+// comes from the context given to Fetch and the client timeout is only a
+// safety net for callers without deadline
+var client = &http.Client{Timeout: 2 * time.Minute}
+
+// StatusError is return when a source answers with an unexpected status code
+type StatusError struct {
+	Code int
+	URL  string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d from %s", e.Code, e.URL)
+}
+
+// Banned reports whether the status code means the source refuses our requests
+func (e *StatusError) Banned() bool {
+	return e.Code == http.StatusForbidden || e.Code == http.StatusTooManyRequests
+}
+
+// checkStatus returns a StatusError when the response is not successful
+func checkStatus(res *http.Response) error {
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return &StatusError{Code: res.StatusCode, URL: res.Request.URL.String()}
+	}
+	return nil
+}
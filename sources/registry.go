@@ -0,0 +1,130 @@
+package sources
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/eleboucher/berlin-vaccine-alert/internals/proxy"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+	"github.com/mitchellh/mapstructure"
+)
+
+// doctolibProxy is shared by every doctolib source since they all hit the same host
+var doctolibProxy = &proxy.Proxy{}
+
+// kinds maps the kind of a source entry in the config file to the constructor
+// of its Fetcher
+var kinds = map[string]func() Fetcher{
+	"doctolib":      func() Fetcher { return &Doctolib{Proxy: doctolibProxy} },
+	"helios":        func() Fetcher { return &Helios{} },
+	"zollsoft":      func() Fetcher { return &Zollsoft{} },
+	"html-scrape":   func() Fetcher { return &Scrape{} },
+	"termin-direkt": func() Fetcher { return &TerminDirekt{} },
+}
+
+// validator is implemented by the fetchers that need to check their
+// parameters once decoded
+type validator interface {
+	Validate() error
+}
+
+// locator is implemented by the fetchers locating their practice, which are
+// all the ones embedding Base
+type locator interface {
+	locate() error
+}
+
+// Load builds the sources described by the entries of the sources section
+// of the config file
+func Load(entries []map[string]interface{}) ([]*Source, error) {
+	sources := make([]*Source, 0, len(entries))
+	names := make(map[string]bool, len(entries))
+
+	for i, entry := range entries {
+		var settings Settings
+		err := decode(entry, &settings)
+		if err != nil {
+			return nil, fmt.Errorf("source %d: %w", i, err)
+		}
+		newFetcher, ok := kinds[settings.Kind]
+		if !ok {
+			return nil, fmt.Errorf("source %d: %w: %q", i, ErrUnknownKind, settings.Kind)
+		}
+		settings.setDefaults()
+
+		fetcher := newFetcher()
+		err = decode(entry, fetcher)
+		if err != nil {
+			return nil, fmt.Errorf("source %d: %w", i, err)
+		}
+
+		if l, ok := fetcher.(locator); ok {
+			err = l.locate()
+			if err != nil {
+				return nil, fmt.Errorf("source %d: %w", i, err)
+			}
+		}
+
+		if v, ok := fetcher.(validator); ok {
+			err = v.Validate()
+			if err != nil {
+				return nil, fmt.Errorf("source %d: %w", i, err)
+			}
+		}
+
+		name := fetcher.Name()
+		if name == "" {
+			return nil, fmt.Errorf("source %d: %w", i, ErrMissingName)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("source %d: %w: %q", i, ErrDuplicateName, name)
+		}
+		names[name] = true
+
+		sources = append(sources, &Source{Fetcher: fetcher, Settings: settings})
+	}
+
+	return sources, nil
+}
+
+func decode(entry map[string]interface{}, result interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			stringToVaccineHookFunc,
+			stringToDoseHookFunc,
+		),
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(entry)
+}
+
+// stringToVaccineHookFunc parses the vaccine names of the config file, so any
+// alias of a vaccine can be used and unknown vaccines are rejected
+func stringToVaccineHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(vaccines.Vaccine("")) {
+		return data, nil
+	}
+	vaccine, err := vaccines.Parse(data.(string))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, data)
+	}
+	return vaccine, nil
+}
+
+// stringToDoseHookFunc parses the doses of the config file, so the German names
+// like "Zweitimpfung" can be used
+func stringToDoseHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(vaccines.DoseUnknown) || data.(string) == "" {
+		return data, nil
+	}
+	dose, err := vaccines.ParseDose(data.(string))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, data)
+	}
+	return dose, nil
+}
@@ -0,0 +1,160 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"text/template"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+const (
+	// scrapeVaccineGroup is the name of the regex group holding the vaccine name
+	scrapeVaccineGroup = "vaccine"
+	// scrapeAmountGroup is the name of the regex group holding the amount of appointments
+	scrapeAmountGroup = "amount"
+	// scrapeDoseGroup is the name of the regex group holding the dose of the appointments
+	scrapeDoseGroup = "dose"
+)
+
+type resultScrape struct {
+	Amount     int
+	Name       string
+	Vaccine    vaccines.Vaccine
+	Phone      string
+	BookingURL string
+}
+
+// Scrape holds the information for fetching the information from a practice
+// publishing its availabilities on its own website
+type Scrape struct {
+	Base     `mapstructure:",squash"`
+	URL      string `mapstructure:"url"`
+	Selector string `mapstructure:"selector"`
+	Regex    string `mapstructure:"regex"`
+	// DetailTemplate renders the detail of the results, with the text matched by the regex
+	DetailTemplate string `mapstructure:"detail_template"`
+
+	regex    *regexp.Regexp
+	template *template.Template
+}
+
+// Validate compiles the regex and the detail template of the source
+func (s *Scrape) Validate() error {
+	if s.URL == "" || s.Selector == "" {
+		return ErrScrapeMissingTarget
+	}
+
+	regex, err := regexp.Compile(s.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid regex: %w", err)
+	}
+	if regex.SubexpIndex(scrapeVaccineGroup) == -1 {
+		return fmt.Errorf("%w: %q", ErrScrapeMissingGroup, scrapeVaccineGroup)
+	}
+	s.regex = regex
+
+	if s.DetailTemplate != "" {
+		t, err := template.New("detail").Parse(s.DetailTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid detail template: %w", err)
+		}
+		s.template = t
+	}
+
+	return nil
+}
+
+// Fetch fetches all the available appointment and filter then and return the results
+func (s *Scrape) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	var ret []*vaccines.Result
+	req, err := http.NewRequestWithContext(ctx, "GET", s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	err = checkStatus(res)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	selection := doc.Find(s.Selector)
+	if selection.Length() == 0 {
+		return nil, fmt.Errorf("%w: %q on %s", ErrScrapeNoMatch, s.Selector, s.URL)
+	}
+
+	selection.Each(
+		func(_ int, sel *goquery.Selection) {
+			for _, match := range s.regex.FindAllStringSubmatch(sel.Text(), -1) {
+				name := match[s.regex.SubexpIndex(scrapeVaccineGroup)]
+				vaccine, err := vaccines.Parse(name)
+				if err != nil {
+					continue
+				}
+				// an optional amount group matching nothing leaves the
+				// appointments uncounted
+				var amount int
+				if i := s.regex.SubexpIndex(scrapeAmountGroup); i != -1 && match[i] != "" {
+					amount, err = strconv.Atoi(match[i])
+					if err != nil {
+						continue
+					}
+				}
+				result := s.newResult(vaccine)
+				result.Amount = int64(amount)
+				if result.Dose == vaccines.DoseUnknown {
+					result.Dose = s.classifyDose(match)
+				}
+				if s.template != nil {
+					detail, err := s.formatDetail(
+						resultScrape{
+							Name:       name,
+							Vaccine:    vaccine,
+							Amount:     amount,
+							Phone:      s.Phone,
+							BookingURL: s.BookingURL,
+						},
+					)
+					if err != nil {
+						continue
+					}
+					result.Detail = detail
+				}
+				ret = append(ret, result)
+			}
+		},
+	)
+	return ret, nil
+}
+
+// classifyDose returns the dose of a match, from the dose group when the regex
+// has one or from the whole matched text otherwise
+func (s *Scrape) classifyDose(match []string) vaccines.Dose {
+	if i := s.regex.SubexpIndex(scrapeDoseGroup); i != -1 {
+		return vaccines.ClassifyDose(match[i])
+	}
+	return vaccines.ClassifyDose(match[0])
+}
+
+func (s *Scrape) formatDetail(result resultScrape) (string, error) {
+	var tpl bytes.Buffer
+	err := s.template.Execute(&tpl, result)
+	if err != nil {
+		return "", err
+	}
+	return tpl.String(), nil
+}
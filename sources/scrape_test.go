@@ -0,0 +1,75 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+func TestScrapeFetch(t *testing.T) {
+	page := `<html><body><ul id="termine">
+		<li>12 Termine BioNTech</li>
+		<li>Termine Moderna</li>
+		<li>3 Termine Grippe</li>
+	</ul></body></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	// want is the vaccine and the amount of a result
+	type want struct {
+		vaccine vaccines.Vaccine
+		amount  int64
+	}
+	tests := []struct {
+		name  string
+		regex string
+		want  []want
+	}{
+		{
+			name:  "amount",
+			regex: `(?P<amount>\d+) Termine (?P<vaccine>\w+)`,
+			want:  []want{{vaccines.Pfizer, 12}},
+		},
+		{
+			name:  "optional amount",
+			regex: `(?P<amount>\d*) ?Termine (?P<vaccine>\w+)`,
+			want:  []want{{vaccines.Pfizer, 12}, {vaccines.Moderna, 0}},
+		},
+		{
+			name:  "no amount",
+			regex: `Termine (?P<vaccine>\w+)`,
+			want:  []want{{vaccines.Pfizer, 0}, {vaccines.Moderna, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scrape{URL: server.URL, Selector: "#termine li", Regex: tt.regex}
+			err := s.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			got, err := s.Fetch(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Fetch() returned %d results, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if got[i].Vaccine != w.vaccine || got[i].Amount != w.amount {
+					t.Errorf("result %d = %s amount %d, want %s amount %d", i, got[i].Vaccine, got[i].Amount, w.vaccine, w.amount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// terminDirektDayLayouts are the layouts the DaysWithFreeIntervals API is known to use
+var terminDirektDayLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// TerminDirekt holds the information for fetching the information for a
+// practice using the termin-direkt.de booking system
+type TerminDirekt struct {
+	Base        `mapstructure:",squash"`
+	Host        string           `mapstructure:"host"`
+	CalendarID  int64            `mapstructure:"calendar_id"`
+	ServiceID   int64            `mapstructure:"service_id"`
+	PersonCount int64            `mapstructure:"person_count"`
+	Vaccine     vaccines.Vaccine `mapstructure:"vaccine_name"`
+	// Days is how many days ahead the free days are looked for
+	Days int `mapstructure:"days"`
+}
+
+// Validate checks the source has a vaccine
+func (t *TerminDirekt) Validate() error {
+	if t.Vaccine == "" {
+		return ErrMissingVaccine
+	}
+	return nil
+}
+
+// TerminDirektResponse is the response of the DaysWithFreeIntervals API
+type TerminDirektResponse struct {
+	Data             []string      `json:"Data"`
+	Success          bool          `json:"Success"`
+	Error            interface{}   `json:"Error"`
+	ValidationErrors []interface{} `json:"ValidationErrors"`
+}
+
+// TerminDirektRequest is the payload of the DaysWithFreeIntervals API
+type TerminDirektRequest struct {
+	CalendarID  int64  `json:"calendarId"`
+	ServiceID   int64  `json:"serviceId"`
+	PersonCount int64  `json:"personCount"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate"`
+}
+
+// Fetch fetches all the available appointment and filter then and return the results
+func (t *TerminDirekt) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	url := fmt.Sprintf("https://%s/rest-v2/api/Calendars/%d/DaysWithFreeIntervals", t.Host, t.CalendarID)
+
+	personCount := t.PersonCount
+	if personCount == 0 {
+		personCount = 1
+	}
+	reqPayload := TerminDirektRequest{
+		CalendarID:  t.CalendarID,
+		ServiceID:   t.ServiceID,
+		PersonCount: personCount,
+		StartDate:   time.Now().Format(time.RFC3339Nano),
+		EndDate:     horizon(t.Days).Format(time.RFC3339Nano),
+	}
+	payload, err := json.Marshal(&reqPayload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("content-type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	err = checkStatus(res)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var resp TerminDirektResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+
+	ret := t.newResult(t.Vaccine)
+	ret.DateOnly = true
+	ret.SetSlots(parseTerminDirektDays(resp.Data))
+
+	return []*vaccines.Result{ret}, nil
+}
+
+// parseTerminDirektDays parses the free days returned by the API, the days
+// that can't be parsed are skipped
+func parseTerminDirektDays(data []string) []time.Time {
+	days := make([]time.Time, 0, len(data))
+	for _, day := range data {
+		for _, layout := range terminDirektDayLayouts {
+			date, err := time.ParseInLocation(layout, day, berlin)
+			if err == nil {
+				days = append(days, date)
+				break
+			}
+		}
+	}
+	return days
+}
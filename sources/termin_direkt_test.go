@@ -0,0 +1,59 @@
+package sources
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTerminDirektDays(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 10, d, 0, 0, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		want    []time.Time
+	}{
+		{
+			name:    "days with offset",
+			fixture: `{"Data": ["2026-10-19T00:00:00+02:00", "2026-10-20T00:00:00+02:00"], "Success": true}`,
+			want:    []time.Time{day(19), day(20)},
+		},
+		{
+			name:    "days without offset are in Berlin",
+			fixture: `{"Data": ["2026-10-19T00:00:00", "2026-10-21"], "Success": true}`,
+			want:    []time.Time{day(19), day(21)},
+		},
+		{
+			name:    "invalid days are skipped",
+			fixture: `{"Data": ["tomorrow", "2026-10-22", "19.10.2026"], "Success": true}`,
+			want:    []time.Time{day(22)},
+		},
+		{
+			name:    "no free day",
+			fixture: `{"Data": [], "Success": true}`,
+			want:    []time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp TerminDirektResponse
+			err := json.Unmarshal([]byte(tt.fixture), &resp)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := parseTerminDirektDays(resp.Data)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseTerminDirektDays() returned %d days, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !got[i].Equal(want) {
+					t.Errorf("day %d = %s, want %s", i, got[i], want)
+				}
+			}
+		})
+	}
+}
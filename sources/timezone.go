@@ -0,0 +1,10 @@
+package sources
+
+import (
+	"time"
+	// embed the timezone database so the Berlin timezone is always available
+	_ "time/tzdata"
+)
+
+// berlin is the timezone the practices publish their slots in
+var berlin, _ = time.LoadLocation("Europe/Berlin")
@@ -0,0 +1,182 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+// zollsoftDateLayouts are the layouts the zollsoft API is known to use for the slot dates
+var zollsoftDateLayouts = []string{
+	"02.01.2006",
+	"2006-01-02",
+}
+
+// Zollsoft holds the information for fetching the information for a practice
+// using the onlinetermine.zollsoft.de booking system
+type Zollsoft struct {
+	Base        `mapstructure:",squash"`
+	UniqueIdent string `mapstructure:"unique_ident"`
+	Insured     bool   `mapstructure:"insured"`
+}
+
+// TMessage is the response of the searchTermine API
+type TMessage struct {
+	Terminsuchen []Terminsuchen `json:"terminsuchen"`
+	Termine      [][]*string    `json:"termine"`
+}
+
+// Terminsuchen is an appointment type offered by the practice
+type Terminsuchen struct {
+	Name string `json:"name"`
+	Nr   int64  `json:"nr"`
+}
+
+// Fetch fetches all the available appointment and filter then and return the results
+func (z *Zollsoft) Fetch(ctx context.Context) ([]*vaccines.Result, error) {
+	endpoint := "https://onlinetermine.zollsoft.de/includes/searchTermine_app_feature.php"
+
+	form := url.Values{}
+	if z.Insured {
+		form.Set("versichert", "1")
+	} else {
+		form.Set("versichert", "0")
+	}
+	form.Set("terminsuche", "")
+	form.Set("uniqueident", z.UniqueIdent)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("content-type", "application/x-www-form-urlencoded; charset=UTF-8")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	err = checkStatus(res)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var resp TMessage
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return z.results(resp), nil
+}
+
+// results returns a result for each Terminsuche of a vaccine having free termine
+func (z *Zollsoft) results(resp TMessage) []*vaccines.Result {
+	slots, dateOnly := parseZollsoftSlots(resp)
+
+	var ret []*vaccines.Result
+
+	for _, a := range resp.Terminsuchen {
+		// Nr identifies the Terminsuche, the free slots are the termine
+		if len(slots[a.Name]) == 0 {
+			continue
+		}
+		if vaccine, err := vaccines.Parse(a.Name); err == nil {
+			result := z.newResult(vaccine)
+			result.Amount = int64(len(slots[a.Name]))
+			if result.Detail == "" {
+				result.Detail = a.Name
+			}
+			if result.Dose == vaccines.DoseUnknown {
+				result.Dose = vaccines.ClassifyDose(a.Name)
+			}
+			result.SetSlots(slots[a.Name])
+			result.DateOnly = dateOnly[a.Name]
+			ret = append(ret, result)
+		}
+	}
+	return ret
+}
+
+// parseZollsoftSlots parses the Termine array of the response and groups the
+// slots by the name of their Terminsuchen.
+// Each termin is an array of strings holding, among others, the date, the time
+// and the name of the Terminsuchen of the slot. When a termin does not name its
+// Terminsuchen and the practice only offers one, the slot is given to it.
+// A termin without time gives a slot at the start of its day, its Terminsuchen
+// is then reported as only knowing the day of its slots.
+func parseZollsoftSlots(resp TMessage) (map[string][]time.Time, map[string]bool) {
+	slots := make(map[string][]time.Time)
+	dateOnly := make(map[string]bool)
+
+	for _, termin := range resp.Termine {
+		var (
+			date, clock time.Time
+			hasDate     bool
+			hasClock    bool
+			name        string
+		)
+		for _, field := range termin {
+			if field == nil {
+				continue
+			}
+			value := strings.TrimSpace(*field)
+			if !hasDate {
+				if d, ok := parseZollsoftDate(value); ok {
+					date, hasDate = d, true
+					continue
+				}
+			}
+			if !hasClock {
+				if c, err := time.Parse("15:04", value); err == nil {
+					clock, hasClock = c, true
+					continue
+				}
+			}
+			if name == "" && isZollsoftTerminsuche(resp.Terminsuchen, value) {
+				name = value
+			}
+		}
+		if !hasDate {
+			continue
+		}
+		if name == "" && len(resp.Terminsuchen) == 1 {
+			name = resp.Terminsuchen[0].Name
+		}
+		if !hasClock {
+			dateOnly[name] = true
+		}
+		slot := time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, berlin)
+		slots[name] = append(slots[name], slot)
+	}
+
+	return slots, dateOnly
+}
+
+func parseZollsoftDate(value string) (time.Time, bool) {
+	for _, layout := range zollsoftDateLayouts {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func isZollsoftTerminsuche(terminsuchen []Terminsuchen, name string) bool {
+	for _, t := range terminsuchen {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package sources
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
+)
+
+func TestZollsoftResults(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, berlin)
+	}
+
+	// want is the result expected for a Terminsuche
+	type want struct {
+		vaccine  vaccines.Vaccine
+		dose     vaccines.Dose
+		detail   string
+		slots    []time.Time
+		dateOnly bool
+	}
+	tests := []struct {
+		name    string
+		fixture string
+		want    []want
+	}{
+		{
+			name: "termine grouped by Terminsuche",
+			fixture: `{
+				"terminsuchen": [
+					{"name": "BioNTech Erstimpfung", "nr": 12},
+					{"name": "BioNTech Zweitimpfung", "nr": 13},
+					{"name": "Grippeimpfung", "nr": 14}
+				],
+				"termine": [
+					["19.10.2026", "09:30", "BioNTech Erstimpfung", null],
+					["19.10.2026", "09:00", "BioNTech Erstimpfung", "Praxis"],
+					["2026-10-20", "17:15", "BioNTech Zweitimpfung"],
+					["20.10.2026", "10:00", "Grippeimpfung"]
+				]
+			}`,
+			want: []want{
+				{vaccine: vaccines.Pfizer, dose: vaccines.DoseFirst, detail: "BioNTech Erstimpfung", slots: []time.Time{at(19, 9, 0), at(19, 9, 30)}},
+				{vaccine: vaccines.Pfizer, dose: vaccines.DoseSecond, detail: "BioNTech Zweitimpfung", slots: []time.Time{at(20, 17, 15)}},
+			},
+		},
+		{
+			name: "Terminsuche without termine",
+			fixture: `{
+				"terminsuchen": [
+					{"name": "Moderna Booster", "nr": 42},
+					{"name": "BioNTech Booster", "nr": 43}
+				],
+				"termine": [
+					["21.10.2026", "11:00", "BioNTech Booster"]
+				]
+			}`,
+			want: []want{
+				{vaccine: vaccines.Pfizer, dose: vaccines.DoseBooster, detail: "BioNTech Booster", slots: []time.Time{at(21, 11, 0)}},
+			},
+		},
+		{
+			name: "single Terminsuche and termine without time",
+			fixture: `{
+				"terminsuchen": [{"name": "Impfung Comirnaty", "nr": 7}],
+				"termine": [
+					["22.10.2026"],
+					["invalid"],
+					["23.10.2026", null]
+				]
+			}`,
+			want: []want{
+				{vaccine: vaccines.Pfizer, detail: "Impfung Comirnaty", slots: []time.Time{at(22, 0, 0), at(23, 0, 0)}, dateOnly: true},
+			},
+		},
+		{
+			name:    "no termine",
+			fixture: `{"terminsuchen": [{"name": "BioNTech", "nr": 1}], "termine": []}`,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp TMessage
+			err := json.Unmarshal([]byte(tt.fixture), &resp)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			z := &Zollsoft{}
+			got := z.results(resp)
+			if len(got) != len(tt.want) {
+				t.Fatalf("results() returned %d results, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				result := got[i]
+				if result.Vaccine != w.vaccine || result.Dose != w.dose || result.Detail != w.detail || result.DateOnly != w.dateOnly {
+					t.Errorf("result %d = %s %q %q date only %v, want %s %q %q date only %v", i,
+						result.Vaccine, result.Dose, result.Detail, result.DateOnly, w.vaccine, w.dose, w.detail, w.dateOnly)
+				}
+				if result.Amount != int64(len(w.slots)) {
+					t.Errorf("result %d amount = %d, want %d", i, result.Amount, len(w.slots))
+				}
+				if len(result.Slots) != len(w.slots) {
+					t.Fatalf("result %d has %d slots, want %d", i, len(result.Slots), len(w.slots))
+				}
+				for j, slot := range w.slots {
+					if !result.Slots[j].Equal(slot) {
+						t.Errorf("result %d slot %d = %s, want %s", i, j, result.Slots[j], slot)
+					}
+				}
+			}
+		})
+	}
+}
@@ -4,11 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/eleboucher/berlin-vaccine-alert/geo"
+	"github.com/eleboucher/berlin-vaccine-alert/locales"
 	"github.com/eleboucher/berlin-vaccine-alert/models/chat"
+	"github.com/eleboucher/berlin-vaccine-alert/models/delivery"
+	"github.com/eleboucher/berlin-vaccine-alert/models/dialog"
+	"github.com/eleboucher/berlin-vaccine-alert/models/digest"
+	"github.com/eleboucher/berlin-vaccine-alert/scheduler"
 	"github.com/eleboucher/berlin-vaccine-alert/vaccines"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -20,68 +28,184 @@ import (
 const (
 	startButton      = "Start"
 	stopButton       = "Stop"
-	filterButton     = "Add filters (multiple choices available)"
-	azButton         = "Look for AstraZeneca"
-	jjButton         = "Look for Johnson & Johnson"
-	vcButton         = "Look for MRNA vaccine (clinics and vaccination centers)"
-	everythingButton = "Look for everything"
+	settingsButton   = "Settings"
+	shareButton      = "Share my location"
 	contributeButton = "Contribute and support"
+	// filterButton, infoFilterButton and backButton are kept for the users
+	// still having the reply keyboards the settings used to be chosen with
+	filterButton     = "Add filters (multiple choices available)"
 	infoFilterButton = "Info about filters"
 	backButton       = "Back"
 )
 
-var keyboard = tgbotapi.NewReplyKeyboard(
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(startButton),
-		tgbotapi.NewKeyboardButton(stopButton),
-	),
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(filterButton),
-	),
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(contributeButton),
-	),
-)
+// keyboardButtons are the buttons of the reply keyboards the users can tap
+var keyboardButtons = []string{startButton, stopButton, settingsButton, contributeButton, filterButton, infoFilterButton, backButton}
 
-var filtersKeyboard = tgbotapi.NewReplyKeyboard(
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(azButton),
-		tgbotapi.NewKeyboardButton(jjButton),
-	),
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(vcButton),
-	),
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(everythingButton),
-	),
-	tgbotapi.NewKeyboardButtonRow(
-		tgbotapi.NewKeyboardButton(infoFilterButton),
-		tgbotapi.NewKeyboardButton(backButton),
-	),
-)
+// legacyVaccineButtons maps the labels of the reply keyboard the vaccines used
+// to be chosen with to the vaccine they add to the filters, the empty vaccine
+// resets them
+var legacyVaccineButtons = map[string]vaccines.Vaccine{
+	"Look for AstraZeneca":                                    vaccines.AstraZeneca,
+	"Look for Johnson & Johnson":                              vaccines.JohnsonAndJohnson,
+	"Look for MRNA vaccine (clinics and vaccination centers)": vaccines.AnyMRNA,
+	"Look for BioNTech/Pfizer":                                vaccines.Pfizer,
+	"Look for Moderna":                                        vaccines.Moderna,
+	"Look for Novavax":                                        vaccines.Novavax,
+	"Look for everything":                                     "",
+}
+
+// replyKeyboard returns the reply keyboard in the language
+func replyKeyboard(language locales.Language) tgbotapi.ReplyKeyboardMarkup {
+	return tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(language.Translate(startButton)),
+			tgbotapi.NewKeyboardButton(language.Translate(stopButton)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(language.Translate(settingsButton)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButtonLocation(language.Translate(shareButton)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(language.Translate(contributeButton)),
+		),
+	)
+}
+
+// buttonLabel returns the English label of the button of a reply keyboard the
+// text is the label of, in any language since the users keep the keyboard
+// they got when changing their language. Other texts are returned as is.
+func buttonLabel(text string) string {
+	for _, language := range locales.Languages() {
+		for _, button := range keyboardButtons {
+			if text == language.Translate(button) {
+				return button
+			}
+		}
+	}
+	return text
+}
+
+// contributeMessage tells how to contribute to the bot
+const contributeMessage = "Hey you 🚀,\nThanks a lot for using the bot,\n\n\nFeel free to contribute on Github: https://github.com/eleboucher/berlin-vaccine-alert\n\n\nOr feel free to contribute on Paypal https://paypal.me/ELeboucher or Buy me a beer https://www.buymeacoffee.com/eleboucher"
+
+// welcomeMessage welcomes the new chats
+const welcomeMessage = `
+Welcome 👋🏼!
+You are now added to the subscription list, you will receive appointments shortly when they will be available
+I hope this bot helps you in your research to get the vaccine!
+
+Provide feedback 📢 on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Feel free to help me with the cost of the bot or with the code, via:
+💸 Donate via PayPal: https://paypal.me/ELeboucher
+🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+
+I really hope it can help you to find your appointment!
+
+Stay Safe, and thanks for your support! ❤️`
+
+// welcomeBackMessage welcomes the chats starting again
+const welcomeBackMessage = `
+Hey Again!
+You are already added to the subscription list, you will receive appointments shortly when they will be available!
+
+I hope this bot helps you in your research to get the vaccine!
+
+Provide feedback 📢 on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Feel free to help me with the cost or with the code, via:
+💸 Donate via PayPal: https://paypal.me/ELeboucher
+🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+
+I really hope it can help you to find your appointment!
+
+Stay Safe, and thanks for your support! ❤️`
+
+// goodbyeMessage is sent to the chats that stop
+const goodbyeMessage = `
+Hey!
+
+You are removed from the list. If you want to receive messages again type /start.
+
+I hope you had book an appointment and you are getting vaccinated soon!
+
+If you have any feedback feel free to post something on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+
+Feel free to help me with the cost of the bot or with the code, via:
+💸 Donate via PayPal: https://paypal.me/ELeboucher
+🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
+🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+
+Stay Safe, and thanks for your support! ❤️`
+
+// startFirstMessage is sent to the chats using the settings or the commands
+// before /start
+const startFirstMessage = "Please send /start first"
+
+// callbackFailedMessage answers the settings buttons that could not be applied
+const callbackFailedMessage = "Something went wrong, please try again"
+
+// quietModeDescriptions describes what happens to the alerts in each quiet
+// mode, in English
+var quietModeDescriptions = map[chat.QuietMode]string{
+	chat.QuietDrop:   "the alerts are dropped",
+	chat.QuietSilent: "the alerts are sent silently",
+	chat.QuietQueue:  "the alerts are sent when they end",
+}
+
+// minDigestInterval is the shortest time between two digests, the digests are
+// sent by a loop ticking every minute
+const minDigestInterval = 5 * time.Minute
+
+// healthReporter reports the health of the sources
+type healthReporter interface {
+	Health() []scheduler.Health
+}
 
 // Telegram Holds the structure for the telegram bot
 type Telegram struct {
-	bot       *tgbotapi.BotAPI
-	limiter   *rate.Limiter
-	chatModel *chat.Model
+	bot           *tgbotapi.BotAPI
+	limiter       *rate.Limiter
+	chatModel     *chat.Model
+	deliveryModel *delivery.Model
+	digestModel   *digest.Model
+	dialogModel   *dialog.Model
+	admins        map[int64]bool
+	health        healthReporter
+	// sources are the names of the configured sources, listed by /sources
+	sources []string
 }
 
 // NewBot return a new Telegram Bot
-func NewBot(bot *tgbotapi.BotAPI, chatModel *chat.Model) *Telegram {
+func NewBot(bot *tgbotapi.BotAPI, chatModel *chat.Model, deliveryModel *delivery.Model, digestModel *digest.Model, dialogModel *dialog.Model, admins map[int64]bool) *Telegram {
 	return &Telegram{
-		bot:       bot,
-		chatModel: chatModel,
-		limiter:   rate.NewLimiter(rate.Every(time.Second/30), 1),
+		bot:           bot,
+		chatModel:     chatModel,
+		deliveryModel: deliveryModel,
+		digestModel:   digestModel,
+		dialogModel:   dialogModel,
+		admins:        admins,
+		limiter:       rate.NewLimiter(rate.Every(time.Second/30), 1),
 	}
 }
 
 // SendMessage send a message in string to a channel id
 func (t *Telegram) SendMessage(message string, channel int64) error {
+	return t.sendMessage(message, channel, false)
+}
+
+// sendMessage send a message in string to a channel id, without notification
+// when silent is set
+func (t *Telegram) sendMessage(message string, channel int64, silent bool) error {
 	msg := tgbotapi.MessageConfig{
 		BaseChat: tgbotapi.BaseChat{
-			ChatID:           channel,
-			ReplyToMessageID: 0,
+			ChatID:              channel,
+			ReplyToMessageID:    0,
+			DisableNotification: silent,
 		},
 		Text:                  message,
 		DisableWebPagePreview: true,
@@ -104,26 +228,103 @@ func (t *Telegram) SendMessage(message string, channel int64) error {
 	return nil
 }
 
-// SendMessageToAllUser send a message to all the enabled users
-func (t *Telegram) SendMessageToAllUser(result *vaccines.Result) error {
-	chats, err := t.chatModel.List(&result.VaccineName)
-	if err != nil {
-		return err
+// SendResultsToAllUser sends the results of a scheduling batch to the enabled
+// users looking for them, grouped in one message per chat and restricted to
+// the slots each of them looks for
+func (t *Telegram) SendResultsToAllUser(results []*vaccines.Result) error {
+	now := time.Now()
+
+	subscribers := make(map[int64]*chat.Chat)
+	matches := make(map[int64][]*vaccines.Result)
+	// keys identifies the results matched by each chat, by their index and
+	// the slots they were restricted to
+	keys := make(map[int64][]string)
+	var chatIDs []int64
+	for i, result := range results {
+		chats, err := t.chatModel.List(result)
+		if err != nil {
+			return err
+		}
+		for _, subscriber := range chats {
+			matched := subscriber.Match(result, now)
+			if matched == nil {
+				continue
+			}
+			if _, ok := subscribers[subscriber.ID]; !ok {
+				subscribers[subscriber.ID] = subscriber
+				chatIDs = append(chatIDs, subscriber.ID)
+			}
+			matches[subscriber.ID] = append(matches[subscriber.ID], matched)
+			key := strconv.Itoa(i)
+			if matched != result {
+				key = fmt.Sprint(i, matched.Slots)
+			}
+			keys[subscriber.ID] = append(keys[subscriber.ID], key)
+		}
 	}
 
+	log.Infof("sending %d results for %d users\n", len(results), len(chatIDs))
+
+	// most chats get the same results, they are rendered once
+	rendered := make(map[string][]string)
 	var wg sync.WaitGroup
+	for _, chatID := range chatIDs {
+		subscriber := subscribers[chatID]
+		matched := matches[chatID]
 
-	wg.Add(len(chats))
-	log.Infof("sending message %s for %d users\n", result.Message, len(chats))
+		until, quiet := subscriber.QuietUntil(now)
+		if quiet && subscriber.QuietMode == chat.QuietDrop {
+			continue
+		}
+		if subscriber.DigestInterval > 0 {
+			for _, result := range matched {
+				err := t.digestModel.Create(chatID, result, now)
+				if err != nil {
+					log.Error(err)
+				}
+			}
+			continue
+		}
 
-	for _, chat := range chats {
-		chat := chat
-		go func() {
-			defer wg.Done()
-			chat := chat
-			err := t.SendMessage(result.Message, chat.ID)
+		// chats matching the same results in the same language get the same messages
+		key := string(subscriber.Language) + " " + strings.Join(keys[chatID], ",")
+		messages, ok := rendered[key]
+		if !ok {
+			var err error
+			messages, err = formatResults(subscriber.Language, matched)
 			if err != nil {
 				log.Error(err)
+				continue
+			}
+			rendered[key] = messages
+		}
+
+		silent := false
+		if quiet {
+			switch subscriber.QuietMode {
+			case chat.QuietQueue:
+				for _, message := range messages {
+					err := t.deliveryModel.Create(chatID, formatQueued(message, now, subscriber), until)
+					if err != nil {
+						log.Error(err)
+					}
+				}
+				continue
+			default:
+				silent = true
+			}
+		}
+
+		wg.Add(1)
+		chatID := chatID
+		go func() {
+			defer wg.Done()
+			for _, message := range messages {
+				err := t.sendMessage(message, chatID, silent)
+				if err != nil {
+					log.Error(err)
+					return
+				}
 			}
 		}()
 	}
@@ -131,214 +332,798 @@ func (t *Telegram) SendMessageToAllUser(result *vaccines.Result) error {
 	return nil
 }
 
-// HandleNewUsers handle the commands from telegrams
-func (t *Telegram) HandleNewUsers() error {
+// DeliverQueued sends the messages queued during the quiet hours of the chats
+// once they are over, until the context is canceled
+func (t *Telegram) DeliverQueued(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("done with queued deliveries")
+			return
+		case now := <-ticker.C:
+			err := t.deliverDue(now)
+			if err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+// deliverDue sends the queued messages due at now and forgets about them
+func (t *Telegram) deliverDue(now time.Time) error {
+	deliveries, err := t.deliveryModel.ListDue(now)
+	if err != nil {
+		return err
+	}
+	for _, d := range deliveries {
+		err := t.SendMessage(d.Message, d.ChatID)
+		if err != nil {
+			log.Error(err)
+		}
+		err = t.deliveryModel.Delete(d.ID)
+		if err != nil {
+			return err
+		}
+	}
+	// the messages of the chats that stopped in the meantime are not delivered
+	return t.deliveryModel.DeleteDue(now)
+}
+
+// SendDigests sends the digests of the chats when they are due, until the
+// context is canceled
+func (t *Telegram) SendDigests(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("done with digests")
+			return
+		case now := <-ticker.C:
+			err := t.sendDueDigests(now)
+			if err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+// sendDueDigests sends the digests due at now. The digests falling in the
+// quiet hours of their chat are sent silently, or when the quiet hours end.
+func (t *Telegram) sendDueDigests(now time.Time) error {
+	chats, err := t.chatModel.ListDigestDue(now)
+	if err != nil {
+		return err
+	}
+	for _, subscriber := range chats {
+		_, quiet := subscriber.QuietUntil(now)
+		if quiet && subscriber.QuietMode != chat.QuietSilent {
+			continue
+		}
+		err := t.sendDigest(subscriber.ID, subscriber.Language, quiet, now)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+	return nil
+}
+
+// sendDigest sends the digest of the chat in the language and starts the next one
+func (t *Telegram) sendDigest(chatID int64, language locales.Language, silent bool, now time.Time) error {
+	entries, err := t.digestModel.List(chatID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	results := make([]*vaccines.Result, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, entry.Result)
+	}
+	message, err := formatDigest(language, results)
+	if err != nil {
+		return err
+	}
+	err = t.sendMessage(message, chatID, silent)
+	if err != nil {
+		return err
+	}
+	err = t.digestModel.DeleteUntil(chatID, entries[len(entries)-1].ID)
+	if err != nil {
+		return err
+	}
+	_, err = t.chatModel.UpdateDigestSentAt(chatID, now)
+	return err
+}
+
+// HandleNewUsers handle the commands from telegrams until the context is canceled
+func (t *Telegram) HandleNewUsers(ctx context.Context) error {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
 	updates := t.bot.GetUpdatesChan(u)
-	for update := range updates {
-		update := update
-		go func() {
-			if update.Message == nil { // ignore any non-Message Updates
-				return
-			}
-			logrus.Infof("Receiving new message: %#v", update.Message)
-			msg := tgbotapi.NewMessage(update.Message.Chat.ID, update.Message.Text)
-			switch update.Message.Text {
-			case "open", backButton:
-				msg.ReplyMarkup = keyboard
-				_, err := t.bot.Send(msg)
-				if err != nil {
-					log.Error(err)
-				}
-			case "close":
-				msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
-				_, err := t.bot.Send(msg)
-				if err != nil {
-					log.Error(err)
-				}
-			case contributeButton:
-				err := t.SendMessage("Hey you 🚀,\nThanks a lot for using the bot,\n\n\nFeel free to contribute on Github: https://github.com/eleboucher/berlin-vaccine-alert\n\n\nOr feel free to contribute on Paypal https://paypal.me/ELeboucher or Buy me a beer https://www.buymeacoffee.com/eleboucher", update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case filterButton:
-				msg.ReplyMarkup = filtersKeyboard
-				_, err := t.bot.Send(msg)
-				if err != nil {
-					log.Error(err)
-				}
-			case stopButton:
-				err := t.stopChat(update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case startButton:
-				err := t.startChat(update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case azButton:
-				_, err := t.chatModel.UpdateFilters(update.Message.Chat.ID, vaccines.AstraZeneca)
-				if err != nil {
-					log.Error(err)
-				}
-				err = t.SendMessage("subscribed to AstraZeneca updates", update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case jjButton:
-				_, err := t.chatModel.UpdateFilters(update.Message.Chat.ID, vaccines.JohnsonAndJohnson)
-				if err != nil {
-					log.Error(err)
-				}
-				err = t.SendMessage("subscribed to Johnson And Johnson updates", update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case vcButton:
-				_, err := t.chatModel.UpdateFilters(update.Message.Chat.ID, vaccines.MRNA)
-				if err != nil {
-					log.Error(err)
-				}
-				err = t.SendMessage("subscribed to MRNA vaccines updates", update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case everythingButton:
-				_, err := t.chatModel.UpdateFilters(update.Message.Chat.ID, "")
-				if err != nil {
-					log.Error(err)
-				}
-				err = t.SendMessage("subscribed to every updates", update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case infoFilterButton:
-				chat, err := t.chatModel.Find(update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-				var filters string
-				if len(chat.Filters) == 0 {
-					filters = "unfiltered"
-				} else {
-					filters = strings.Join(chat.Filters, "\n")
-				}
-				msg := fmt.Sprintf("your current filters are :\n%s\n\nSelect %s to reset them", filters, everythingButton)
-				err = t.SendMessage(msg, update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
+	for {
+		select {
+		case <-ctx.Done():
+			t.bot.StopReceivingUpdates()
+			log.Info("done with telegram handler")
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				log.Info("done with telegram handler")
+				return nil
 			}
+			go t.handleUpdate(update)
+		}
+	}
+}
 
-			switch update.Message.Command() {
-			case "start":
-				err := t.startChat(update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case "stop":
-				err := t.stopChat(update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
-			case "open":
-				msg.ReplyMarkup = filtersKeyboard
-				_, err := t.bot.Send(msg)
-				if err != nil {
-					log.Error(err)
-				}
-			case "contribute":
-				err := t.SendMessage("Hey you 🚀,\nThanks a lot for using the bot,\n\n\nFeel free to contribute on Github: https://github.com/eleboucher/berlin-vaccine-alert\n\n\nOr feel free to contribute on Paypal https://paypal.me/ELeboucher or Buy me a beer https://www.buymeacoffee.com/eleboucher", update.Message.Chat.ID)
-				if err != nil {
-					log.Error(err)
-				}
+// handleUpdate handle a single update from telegram
+func (t *Telegram) handleUpdate(update tgbotapi.Update) {
+	if update.CallbackQuery != nil {
+		err := t.handleCallback(update.CallbackQuery)
+		if err != nil {
+			log.Error(err)
+		}
+		return
+	}
+	if update.Message == nil { // ignore any non-Message Updates
+		return
+	}
+	logrus.Infof("Receiving new message: %#v", update.Message)
+	chatID := update.Message.Chat.ID
+	language := t.language(chatID, update.Message.From)
+	if update.Message.Location != nil {
+		location := geo.Point{Latitude: update.Message.Location.Latitude, Longitude: update.Message.Location.Longitude}
+		err := t.setLocation(chatID, location, language.Translate("your location is saved"))
+		if err != nil {
+			log.Error(err)
+		}
+		// the location answers the question about the postcode
+		err = t.dialogModel.DeleteStep(chatID, stepPostcode)
+		if err != nil {
+			log.Error(err)
+		}
+		return
+	}
+	text := buttonLabel(update.Message.Text)
+	if !update.Message.IsCommand() && !isKeyboardButton(text) {
+		answered, err := t.answerDialog(chatID, language, update.Message.Text)
+		if err != nil {
+			log.Error(err)
+		}
+		if answered {
+			return
+		}
+	} else if update.Message.Command() != "cancel" {
+		// commands and buttons drop the pending question
+		err := t.dialogModel.Delete(chatID)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+	msg := tgbotapi.NewMessage(chatID, update.Message.Text)
+	switch text {
+	case "open", backButton:
+		msg.ReplyMarkup = replyKeyboard(language)
+		_, err := t.bot.Send(msg)
+		if err != nil {
+			log.Error(err)
+		}
+	case "close":
+		msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+		_, err := t.bot.Send(msg)
+		if err != nil {
+			log.Error(err)
+		}
+	case contributeButton:
+		err := t.SendMessage(language.Translate(contributeMessage), chatID)
+		if err != nil {
+			log.Error(err)
+		}
+	case settingsButton, infoFilterButton:
+		err := t.sendSettings(chatID, pageMenu)
+		if err != nil {
+			log.Error(err)
+		}
+	case filterButton:
+		err := t.sendSettings(chatID, pageVaccines)
+		if err != nil {
+			log.Error(err)
+		}
+	case stopButton:
+		err := t.stopChat(chatID, language)
+		if err != nil {
+			log.Error(err)
+		}
+	case startButton:
+		err := t.startChat(chatID, language)
+		if err != nil {
+			log.Error(err)
+		}
+	default:
+		if vaccine, ok := legacyVaccineButtons[text]; ok {
+			err := t.lookForVaccine(chatID, language, vaccine)
+			if err != nil {
+				log.Error(err)
 			}
-		}()
+		} else if place, err := geo.Locate(update.Message.Text); err == nil {
+			err = t.setLocation(chatID, place.Point, language.Sprintf("your location is set to %s (%s)", place.Postcode, place.District))
+			if err != nil {
+				log.Error(err)
+			}
+		}
 	}
 
-	log.Info("done with telegram handler")
-	return nil
+	switch update.Message.Command() {
+	case "start":
+		err := t.startChat(chatID, language)
+		if err != nil {
+			log.Error(err)
+		}
+	case "stop":
+		err := t.stopChat(chatID, language)
+		if err != nil {
+			log.Error(err)
+		}
+	case "open", "settings":
+		err := t.sendSettings(chatID, pageMenu)
+		if err != nil {
+			log.Error(err)
+		}
+	case "cancel":
+		err := t.cancelDialog(chatID, language)
+		if err != nil {
+			log.Error(err)
+		}
+	case "postcode":
+		err := t.askDialog(chatID, language, stepPostcode, nil)
+		if err != nil {
+			log.Error(err)
+		}
+	case "language":
+		args := strings.TrimSpace(update.Message.CommandArguments())
+		var err error
+		if args == "" {
+			err = t.sendSettings(chatID, pageLanguage)
+		} else if chosen, parseErr := locales.Parse(args); parseErr == nil {
+			_, err = t.updateLanguage(chatID, chosen)
+		} else {
+			err = t.SendMessage(language.Sprintf("Send /language followed by %s", formatLanguages()), chatID)
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "days":
+		if strings.TrimSpace(update.Message.CommandArguments()) == "" {
+			err := t.askDialog(chatID, language, stepDays, nil)
+			if err != nil {
+				log.Error(err)
+			}
+			return
+		}
+		days, err := strconv.Atoi(strings.TrimSpace(update.Message.CommandArguments()))
+		if err != nil || days < 0 {
+			err = t.SendMessage(language.Translate("Send /days followed by how many days ahead you look for appointments, 0 for any date"), chatID)
+		} else {
+			err = t.setDays(chatID, days)
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "slots":
+		var err error
+		if strings.TrimSpace(update.Message.CommandArguments()) == "" {
+			err = t.askDialog(chatID, language, stepSlots, nil)
+		} else {
+			err = t.addSlotWindow(chatID, language, update.Message.CommandArguments())
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "quiet":
+		args := strings.TrimSpace(update.Message.CommandArguments())
+		var err error
+		if args == "" {
+			err = t.askDialog(chatID, language, stepQuiet, nil)
+		} else if args == "off" {
+			err = t.setQuietHours(chatID, nil)
+		} else if quietHours, parseErr := chat.ParseQuietHours(args); parseErr == nil {
+			err = t.setQuietHours(chatID, &quietHours)
+		} else {
+			err = t.SendMessage(language.Translate("Send /quiet followed by hours like \"22:00-07:00\", or /quiet off"), chatID)
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "timezone":
+		timezone := strings.TrimSpace(update.Message.CommandArguments())
+		var err error
+		if timezone == "" {
+			err = t.askDialog(chatID, language, stepTimezone, nil)
+		} else if _, parseErr := time.LoadLocation(timezone); parseErr == nil {
+			err = t.setTimezone(chatID, timezone)
+		} else {
+			err = t.SendMessage(language.Translate("Send /timezone followed by a timezone like \"Europe/Berlin\""), chatID)
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "digest":
+		args := strings.TrimSpace(update.Message.CommandArguments())
+		var err error
+		if args == "" {
+			err = t.askDialog(chatID, language, stepDigest, nil)
+		} else if args == "off" {
+			err = t.setDigestInterval(chatID, language, 0)
+		} else if interval, parseErr := time.ParseDuration(args); parseErr == nil && interval >= minDigestInterval {
+			err = t.setDigestInterval(chatID, language, interval)
+		} else {
+			err = t.SendMessage(language.Sprintf("Send /digest followed by a duration of at least %s like \"90m\" or \"6h\", or /digest off", minDigestInterval), chatID)
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "min":
+		var err error
+		if strings.TrimSpace(update.Message.CommandArguments()) == "" {
+			err = t.askDialog(chatID, language, stepMinAmount, nil)
+		} else {
+			err = t.parseMinAmount(chatID, language, update.Message.CommandArguments())
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "filter":
+		err := t.updateFilters(chatID, language, update.Message.CommandArguments())
+		if err != nil {
+			log.Error(err)
+		}
+	case "sources":
+		err := t.sendSettings(chatID, pageSources)
+		if err != nil {
+			log.Error(err)
+		}
+	case "district":
+		var err error
+		if strings.TrimSpace(update.Message.CommandArguments()) == "" {
+			err = t.askDialog(chatID, language, stepDistrict, nil)
+		} else {
+			err = t.addDistrict(chatID, language, update.Message.CommandArguments())
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	case "health":
+		if !t.admins[chatID] || t.health == nil {
+			return
+		}
+		err := t.SendMessage(formatHealth(t.health.Health(), time.Now()), chatID)
+		if err != nil {
+			log.Error(err)
+		}
+	case "contribute":
+		err := t.SendMessage(language.Translate(contributeMessage), chatID)
+		if err != nil {
+			log.Error(err)
+		}
+	}
 }
 
-func (t *Telegram) startChat(chatID int64) error {
-	log.Infof("adding chat %d\n", chatID)
+// language returns the language the chat is talked to in. The chats that have
+// none yet, like the ones created before the bot spoke German, get the
+// language of the telegram user.
+func (t *Telegram) language(chatID int64, user *tgbotapi.User) locales.Language {
+	language := locales.English
+	if user != nil {
+		language = locales.FromCode(user.LanguageCode)
+	}
 
-	_, err := t.chatModel.Create(chatID)
+	subscriber, err := t.chatModel.Find(chatID)
 	if err != nil {
-		if errors.Is(err, chat.ErrChatAlreadyExist) {
-			_, err := t.chatModel.Enable(chatID)
-			if err != nil {
-				return err
-			}
-			err = t.SendMessage(`
-Hey Again!
-You are already added to the subscription list, you will receive appointments shortly when they will be available!
+		if !errors.Is(err, chat.ErrChatNotFound) {
+			log.Error(err)
+		}
+		return language
+	}
+	if subscriber.Language != "" {
+		return subscriber.Language
+	}
+	_, err = t.chatModel.UpdateLanguage(chatID, language)
+	if err != nil {
+		log.Error(err)
+	}
+	return language
+}
 
-I hope this bot helps you in your research to get the vaccine!
+// updateLanguage saves the language the chat is talked to in, and sends the
+// reply keyboard in that language
+func (t *Telegram) updateLanguage(chatID int64, language locales.Language) (*chat.Chat, error) {
+	chat, err := t.chatModel.UpdateLanguage(chatID, language)
+	if err != nil {
+		return nil, err
+	}
+	msg := tgbotapi.NewMessage(chatID, language.Sprintf("I speak %s with you from now on", language.Name()))
+	msg.ReplyMarkup = replyKeyboard(language)
+	_, err = t.bot.Send(msg)
+	return chat, err
+}
 
-Provide feedback 📢 on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+// formatLanguages lists the codes of the languages the bot speaks
+func formatLanguages() string {
+	codes := make([]string, 0, len(locales.Languages()))
+	for _, language := range locales.Languages() {
+		codes = append(codes, fmt.Sprintf("%q", string(language)))
+	}
+	return strings.Join(codes, ", ")
+}
 
-Feel free to help me with the cost or with the code, via:
-💸 Donate via PayPal: https://paypal.me/ELeboucher
-🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
-🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+// formatSources describes the sources the chat subscribed to or muted
+func formatSources(chat *chat.Chat) string {
+	sources := chat.Language.Translate("you receive the alerts of every source")
+	if len(chat.Sources) != 0 {
+		sources = chat.Language.Sprintf("you only receive the alerts of %s", strings.Join(chat.Sources, ", "))
+	}
+	if len(chat.MutedSources) != 0 {
+		sources += chat.Language.Sprintf(", except %s", strings.Join(chat.MutedSources, ", "))
+	}
+	return sources
+}
 
-I really hope it can help you to find your appointment!
+// formatHealth describes the state of every source
+func formatHealth(health []scheduler.Health, now time.Time) string {
+	lines := make([]string, 0, len(health))
+	for _, h := range health {
+		line := fmt.Sprintf("%s: %s", h.Name, h.State)
+		if h.ConsecutiveFailures != 0 {
+			line += fmt.Sprintf(", %d failures (%s: %s)", h.ConsecutiveFailures, h.LastErrorKind, h.LastError)
+		}
+		if h.State == scheduler.Open {
+			line += fmt.Sprintf(", retried in %s", h.OpenUntil.Sub(now).Round(time.Minute))
+		}
+		if !h.LastSuccess.IsZero() {
+			line += fmt.Sprintf(", last success %s ago", now.Sub(h.LastSuccess).Round(time.Minute))
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "no source is configured"
+	}
+	return strings.Join(lines, "\n")
+}
 
-Stay Safe, and thanks for your support! ❤️`, chatID)
-			if err != nil {
-				return err
+// updateFilters applies the /filter command to the filters of the chat, it adds
+// or removes the vaccine named after the operation, replaces the filters by
+// the comma-separated vaccines or lists them
+func (t *Telegram) updateFilters(chatID int64, language locales.Language, text string) error {
+	usage := language.Translate("Send /filter add, remove or set followed by a vaccine like \"pfizer\" or \"mrna\", set takes several vaccines separated by commas. Send /filter list to list your filters")
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return t.SendMessage(usage, chatID)
+	}
+	operation := strings.ToLower(fields[0])
+	args := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), fields[0]))
+
+	var (
+		subscriber *chat.Chat
+		err        error
+	)
+	switch operation {
+	case "list":
+		subscriber, err = t.chatModel.Find(chatID)
+	case "add", "remove":
+		vaccine, parseErr := vaccines.Parse(args)
+		if parseErr != nil {
+			return t.SendMessage(language.Sprintf("%q is not a known vaccine", args)+"\n\n"+usage, chatID)
+		}
+		if operation == "add" {
+			subscriber, err = t.chatModel.AddFilter(chatID, string(vaccine))
+		} else {
+			subscriber, err = t.chatModel.RemoveFilter(chatID, string(vaccine))
+		}
+	case "set":
+		var filters []string
+		for _, name := range strings.Split(args, ",") {
+			vaccine, parseErr := vaccines.Parse(name)
+			if parseErr != nil {
+				return t.SendMessage(language.Sprintf("%q is not a known vaccine", strings.TrimSpace(name))+"\n\n"+usage, chatID)
 			}
-			return err
+			filters = append(filters, string(vaccine))
 		}
+		subscriber, err = t.chatModel.ReplaceFilters(chatID, filters)
+	default:
+		return t.SendMessage(usage, chatID)
+	}
+	if err != nil {
 		return err
 	}
-	err = t.SendMessage(`
-Welcome 👋🏼!
-You are now added to the subscription list, you will receive appointments shortly when they will be available
-I hope this bot helps you in your research to get the vaccine!
+	return t.SendMessage(formatFilters(subscriber), chatID)
+}
 
-Provide feedback 📢 on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+// lookForVaccine adds the vaccine of a legacy button to the filters of the
+// chat, the empty vaccine resets them
+func (t *Telegram) lookForVaccine(chatID int64, language locales.Language, vaccine vaccines.Vaccine) error {
+	var (
+		subscriber *chat.Chat
+		err        error
+	)
+	if vaccine == "" {
+		subscriber, err = t.chatModel.ReplaceFilters(chatID, nil)
+	} else {
+		subscriber, err = t.chatModel.AddFilter(chatID, string(vaccine))
+	}
+	if err != nil {
+		if errors.Is(err, chat.ErrChatNotFound) {
+			return t.SendMessage(language.Translate(startFirstMessage), chatID)
+		}
+		return err
+	}
+	return t.SendMessage(formatFilters(subscriber), chatID)
+}
 
-Feel free to help me with the cost of the bot or with the code, via:
-💸 Donate via PayPal: https://paypal.me/ELeboucher
-🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
-🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+// setLocation saves the home of the chat and asks for a distance when it has
+// none, the text tells how the location was found
+func (t *Telegram) setLocation(chatID int64, location geo.Point, text string) error {
+	chat, err := t.chatModel.UpdateLocation(chatID, &location)
+	if err != nil {
+		return err
+	}
+	if chat.MaxDistance == 0 {
+		text += chat.Language.Translate(", choose the maximum distance to the practices")
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = t.settingsKeyboard(chat, pageWhere)
+		_, err = t.bot.Send(msg)
+		return err
+	}
+	return t.SendMessage(text, chatID)
+}
 
-I really hope it can help you to find your appointment!
+// addDistrict adds the district named by the user to the districts of the chat,
+// or lists the districts when none is named
+func (t *Telegram) addDistrict(chatID int64, language locales.Language, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return t.SendMessage(language.Sprintf("Send /district followed by one of:\n%s", strings.Join(geo.Districts(), "\n")), chatID)
+	}
+	district, err := geo.ParseDistrict(name)
+	if err != nil {
+		return t.SendMessage(language.Sprintf("%q is not a Berlin district, send /district to list them", name), chatID)
+	}
+	_, err = t.chatModel.UpdateDistricts(chatID, district)
+	if err != nil {
+		return err
+	}
+	return t.SendMessage(language.Sprintf("subscribed to the appointments in %s", district), chatID)
+}
 
-Stay Safe, and thanks for your support! ❤️`, chatID)
+// setDays saves how many days ahead the chat looks for slots
+func (t *Telegram) setDays(chatID int64, days int) error {
+	chat, err := t.chatModel.UpdateDays(chatID, days)
 	if err != nil {
 		return err
 	}
-	return nil
+	return t.SendMessage(formatWhen(chat), chatID)
 }
 
-func (t *Telegram) stopChat(chatID int64) error {
-	log.Infof("removing chat %d\n", chatID)
+// addSlotWindow adds the slot window written by the user to the chat
+func (t *Telegram) addSlotWindow(chatID int64, language locales.Language, text string) error {
+	window, err := chat.ParseSlotWindow(text)
+	if err != nil {
+		return t.SendMessage(language.Translate("Send /slots followed by days and an optional time range, like \"mon-fri 17:00-24:00\", \"weekend\" or \"sat 10:00-14:00\""), chatID)
+	}
+	_, err = t.chatModel.UpdateSlotWindows(chatID, &window)
+	if err != nil {
+		return err
+	}
+	return t.SendMessage(language.Sprintf("subscribed to the appointments on %s", window), chatID)
+}
 
-	_, err := t.chatModel.Delete(chatID)
+// setTimezone saves the timezone of the chat
+func (t *Telegram) setTimezone(chatID int64, timezone string) error {
+	chat, err := t.chatModel.UpdateTimezone(chatID, timezone)
 	if err != nil {
 		return err
 	}
-	err = t.SendMessage(`
-Hey!
+	return t.SendMessage(chat.Language.Sprintf("your timezone is %s", chat.Timezone), chatID)
+}
 
-You are removed from the list. If you want to receive messages again type /start.
+// setQuietHours saves the quiet hours of the chat, nil removes them
+func (t *Telegram) setQuietHours(chatID int64, quietHours *chat.QuietHours) error {
+	chat, err := t.chatModel.UpdateQuietHours(chatID, quietHours)
+	if err != nil {
+		return err
+	}
+	return t.SendMessage(formatQuiet(chat), chatID)
+}
 
-I hope you had book an appointment and you are getting vaccinated soon!
+// setDigestInterval saves the time between two digests of the chat, zero
+// sends the alerts instantly
+func (t *Telegram) setDigestInterval(chatID int64, language locales.Language, interval time.Duration) error {
+	chat, err := t.updateDigestInterval(chatID, language, interval)
+	if err != nil {
+		return err
+	}
+	return t.SendMessage(formatDelivery(chat), chatID)
+}
 
-If you have any feedback feel free to post something on Reddit: https://www.reddit.com/r/berlinvaccination/comments/np81h5/telegram_bot_to_get_a_vaccine_appointment/
+// updateDigestInterval saves the time between two digests of the chat, the
+// pending digest is sent first when the chat goes back to instant alerts
+func (t *Telegram) updateDigestInterval(chatID int64, language locales.Language, interval time.Duration) (*chat.Chat, error) {
+	if interval == 0 {
+		err := t.sendDigest(chatID, language, false, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t.chatModel.UpdateDigestInterval(chatID, interval)
+}
 
-Feel free to help me with the cost of the bot or with the code, via:
-💸 Donate via PayPal: https://paypal.me/ELeboucher
-🍻 Buy me a beer: https://www.buymeacoffee.com/eleboucher
-🧑‍💻 Contribute to the code:  https://github.com/eleboucher/berlin-vaccine-alert
+// parseMinAmount saves the minimum of free slots written by the user, a number
+// optionally followed by a vaccine
+func (t *Telegram) parseMinAmount(chatID int64, language locales.Language, text string) error {
+	args := strings.Fields(text)
+	usage := language.Translate("Send /min followed by the minimum of free slots and an optional vaccine like \"5 pfizer\" or \"3 mrna\", 0 removes the minimum")
+	if len(args) == 0 {
+		return t.SendMessage(usage, chatID)
+	}
+	amount, err := strconv.Atoi(args[0])
+	if err != nil || amount < 0 {
+		return t.SendMessage(usage, chatID)
+	}
+	var vaccine vaccines.Vaccine
+	if len(args) > 1 {
+		vaccine, err = vaccines.Parse(strings.Join(args[1:], " "))
+		if err != nil {
+			return t.SendMessage(usage, chatID)
+		}
+	}
+	return t.setMinAmount(chatID, string(vaccine), amount)
+}
 
-Stay Safe, and thanks for your support! ❤️`, chatID)
+// setMinAmount saves the minimum of free slots the chat wants to be alerted of,
+// for the vaccine when set and for every vaccine otherwise
+func (t *Telegram) setMinAmount(chatID int64, vaccine string, amount int) error {
+	chat, err := t.chatModel.UpdateMinAmount(chatID, vaccine, amount)
+	if err != nil {
+		return err
+	}
+	return t.SendMessage(formatAmount(chat), chatID)
+}
+
+// formatAmount describes the minimum of free slots the chat wants to be
+// alerted of
+func formatAmount(chat *chat.Chat) string {
+	amount := chat.Language.Translate("you receive the alerts whatever their amount of slots")
+	if chat.MinAmount != 0 {
+		amount = chat.Language.Sprintf("you receive the alerts of at least %d slots", chat.MinAmount)
+	}
+	if len(chat.MinAmounts) != 0 {
+		vaccineIDs := make([]string, 0, len(chat.MinAmounts))
+		for vaccine := range chat.MinAmounts {
+			vaccineIDs = append(vaccineIDs, vaccine)
+		}
+		sort.Strings(vaccineIDs)
+		overrides := make([]string, 0, len(vaccineIDs))
+		for _, vaccine := range vaccineIDs {
+			overrides = append(overrides, chat.Language.Sprintf("%d for %s", chat.MinAmounts[vaccine], chat.Language.Translate(vaccines.FilterName(vaccine))))
+		}
+		amount += ", " + strings.Join(overrides, ", ")
+	}
+	if chat.SkipUncounted {
+		amount += chat.Language.Translate(", skipping the ones whose amount is unknown when there is a minimum")
+	}
+	return amount
+}
+
+// formatDelivery describes how the alerts are sent to the chat
+func formatDelivery(chat *chat.Chat) string {
+	if chat.DigestInterval == 0 {
+		return chat.Language.Translate("you receive the alerts instantly")
+	}
+	return chat.Language.Sprintf("you receive the alerts in a digest every %s", formatInterval(chat.DigestInterval))
+}
+
+// formatInterval formats a duration without its zero units, like "2h" rather than "2h0m0s"
+func formatInterval(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+// formatQuiet describes the quiet hours of the chat
+func formatQuiet(chat *chat.Chat) string {
+	if chat.QuietHours == nil {
+		return chat.Language.Translate("you have no quiet hours")
+	}
+	return chat.Language.Sprintf("your quiet hours are %s (%s), %s", chat.QuietHours, chat.Timezone, chat.Language.Translate(quietModeDescriptions[chat.QuietMode]))
+}
+
+// formatQueued prefixes a message queued during the quiet hours of the chat
+// with when it was found, since the appointments may be gone by then
+func formatQueued(message string, foundAt time.Time, chat *chat.Chat) string {
+	return chat.Language.Sprintf("🌙 found at %s during your quiet hours", foundAt.In(chat.TimeLocation()).Format("15:04")) + "\n\n" + message
+}
+
+// formatWhen describes the dates the chat looks for
+func formatWhen(chat *chat.Chat) string {
+	when := chat.Language.Translate("you look for appointments at any date")
+	if chat.Days != 0 {
+		when = chat.Language.Sprintf("you look for appointments within %d days", chat.Days)
+	}
+	if len(chat.SlotWindows) != 0 {
+		windows := make([]string, 0, len(chat.SlotWindows))
+		for _, window := range chat.SlotWindows {
+			windows = append(windows, window.String())
+		}
+		when += chat.Language.Sprintf(", on %s", strings.Join(windows, chat.Language.Translate(" or ")))
+	}
+	if chat.SkipTimeUnknown {
+		when += chat.Language.Translate(", skipping the ones whose time is unknown")
+	}
+	return when
+}
+
+// formatWhere describes the location preferences of the chat
+func formatWhere(chat *chat.Chat) string {
+	var where []string
+	if len(chat.Districts) != 0 {
+		where = append(where, chat.Language.Sprintf("in %s", strings.Join(chat.Districts, ", ")))
+	}
+	if chat.MaxDistance != 0 {
+		if chat.Location == nil {
+			where = append(where, chat.Language.Sprintf("within %g km (no location shared yet)", chat.MaxDistance))
+		} else {
+			where = append(where, chat.Language.Sprintf("within %g km", chat.MaxDistance))
+		}
+	}
+	if len(where) == 0 {
+		return chat.Language.Translate("you look for appointments anywhere in Berlin")
+	}
+	return chat.Language.Sprintf("you look for appointments %s", strings.Join(where, chat.Language.Translate(" or ")))
+}
+
+func (t *Telegram) startChat(chatID int64, language locales.Language) error {
+	log.Infof("adding chat %d\n", chatID)
+
+	_, err := t.chatModel.Create(chatID, language)
+	if err != nil {
+		if errors.Is(err, chat.ErrChatAlreadyExist) {
+			_, err := t.chatModel.Enable(chatID)
+			if err != nil {
+				return err
+			}
+			err = t.SendMessage(language.Translate(welcomeBackMessage), chatID)
+			if err != nil {
+				return err
+			}
+			return err
+		}
+		return err
+	}
+	err = t.SendMessage(language.Translate(welcomeMessage), chatID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Telegram) stopChat(chatID int64, language locales.Language) error {
+	log.Infof("removing chat %d\n", chatID)
+
+	_, err := t.chatModel.Delete(chatID)
+	if err != nil {
+		return err
+	}
+	err = t.SendMessage(language.Translate(goodbyeMessage), chatID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,249 @@
+package vaccines
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Vaccine is the canonical identifier of a vaccine, it is what the chats store
+// in their filters
+type Vaccine string
+
+const (
+	// AstraZeneca is the AstraZeneca vaccine (Vaxzevria)
+	AstraZeneca Vaccine = "astra"
+	// JohnsonAndJohnson is the Johnson & Johnson vaccine (Janssen)
+	JohnsonAndJohnson Vaccine = "johnson"
+	// Pfizer is the BioNTech/Pfizer vaccine (Comirnaty)
+	Pfizer Vaccine = "pfizer"
+	// Moderna is the Moderna vaccine (Spikevax)
+	Moderna Vaccine = "moderna"
+	// Novavax is the Novavax vaccine (Nuvaxovid)
+	Novavax Vaccine = "novavax"
+	// PfizerAdapted is the BioNTech/Pfizer booster adapted to the variants
+	PfizerAdapted Vaccine = "pfizer-adapted"
+	// ModernaAdapted is the Moderna booster adapted to the variants
+	ModernaAdapted Vaccine = "moderna-adapted"
+	// AnyMRNA is an mRNA vaccine the source does not tell the product of, it
+	// shares its identifier with the MRNA family for the filters saved before
+	// the products were told apart
+	AnyMRNA Vaccine = "MRNA"
+)
+
+// Family groups the vaccines built on the same technology
+type Family string
+
+const (
+	// MRNA is the family of the mRNA vaccines
+	MRNA Family = "MRNA"
+	// Vector is the family of the viral vector vaccines
+	Vector Family = "vector"
+	// Protein is the family of the protein subunit vaccines
+	Protein Family = "protein"
+)
+
+// Info describes a vaccine of the catalogue
+type Info struct {
+	ID      Vaccine
+	Name    string
+	Family  Family
+	Aliases []string
+}
+
+// catalogue lists the known vaccines, the order matters when parsing: the
+// products come before AnyMRNA so "mRNA BioNTech" is parsed as Pfizer
+var catalogue = []Info{
+	{
+		ID:      AstraZeneca,
+		Name:    "AstraZeneca",
+		Family:  Vector,
+		Aliases: []string{"astrazeneca", "astra zeneca", "astra", "vaxzevria", "az"},
+	},
+	{
+		ID:      JohnsonAndJohnson,
+		Name:    "Johnson & Johnson",
+		Family:  Vector,
+		Aliases: []string{"johnson & johnson", "johnson", "j&j", "janssen", "jcovden"},
+	},
+	{
+		ID:      Pfizer,
+		Name:    "BioNTech/Pfizer",
+		Family:  MRNA,
+		Aliases: []string{"biontech", "pfizer", "comirnaty", "bnt162b2"},
+	},
+	{
+		ID:      Moderna,
+		Name:    "Moderna",
+		Family:  MRNA,
+		Aliases: []string{"moderna", "spikevax", "mrna-1273"},
+	},
+	{
+		ID:      Novavax,
+		Name:    "Novavax",
+		Family:  Protein,
+		Aliases: []string{"novavax", "nuvaxovid"},
+	},
+	{
+		ID:     PfizerAdapted,
+		Name:   "BioNTech/Pfizer adapted booster",
+		Family: MRNA,
+	},
+	{
+		ID:     ModernaAdapted,
+		Name:   "Moderna adapted booster",
+		Family: MRNA,
+	},
+	{
+		ID:      AnyMRNA,
+		Name:    "mRNA vaccine (BioNTech or Moderna)",
+		Family:  MRNA,
+		Aliases: []string{"mrna", "mrna-impfstoff", "mrna impfstoff"},
+	},
+}
+
+// adapted maps the products to their booster adapted to the variants
+var adapted = map[Vaccine]Vaccine{
+	Pfizer:  PfizerAdapted,
+	Moderna: ModernaAdapted,
+}
+
+// adaptedMarkers are the words telling a product is the adapted booster
+var adaptedMarkers = []string{
+	"adapted", "angepasst", "bivalent", "omicron", "omikron", "ba.1", "ba.4", "ba.5", "xbb",
+}
+
+// ErrVaccineNotFound is return when the vaccine can't be found
+var ErrVaccineNotFound = errors.New("vaccine not found")
+
+var (
+	// aliasRegexes matches the aliases of each vaccine as whole words
+	aliasRegexes = compileAliases()
+	// canonical indexes the vaccines by lower cased identifier
+	canonical = indexCatalogue()
+)
+
+func compileAliases() map[Vaccine]*regexp.Regexp {
+	regexes := make(map[Vaccine]*regexp.Regexp, len(catalogue))
+	for _, info := range catalogue {
+		if len(info.Aliases) == 0 {
+			continue
+		}
+		quoted := make([]string, 0, len(info.Aliases))
+		for _, alias := range info.Aliases {
+			quoted = append(quoted, regexp.QuoteMeta(alias))
+		}
+		regexes[info.ID] = regexp.MustCompile(`(^|[^\pL\pN])(` + strings.Join(quoted, "|") + `)($|[^\pL\pN])`)
+	}
+	return regexes
+}
+
+func indexCatalogue() map[string]Vaccine {
+	index := make(map[string]Vaccine, len(catalogue))
+	for _, info := range catalogue {
+		index[strings.ToLower(string(info.ID))] = info.ID
+	}
+	return index
+}
+
+// Catalogue returns the known vaccines
+func Catalogue() []Info {
+	return append([]Info(nil), catalogue...)
+}
+
+// Parse returns the vaccine named in the text, the text can be a canonical
+// identifier or contain any alias of a vaccine in German or English
+func Parse(text string) (Vaccine, error) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if vaccine, ok := canonical[lower]; ok {
+		return vaccine, nil
+	}
+
+	var found []Info
+	for _, info := range catalogue {
+		if regex, ok := aliasRegexes[info.ID]; ok && regex.MatchString(lower) {
+			found = append(found, info)
+		}
+	}
+	if len(found) == 0 {
+		return "", ErrVaccineNotFound
+	}
+
+	vaccine := found[0].ID
+	// a text naming several mRNA products, like "BioNTech/Moderna", does not
+	// tell which one will be given
+	if len(found) > 1 && found[0].Family == MRNA && found[1].Family == MRNA && found[1].ID != AnyMRNA {
+		vaccine = AnyMRNA
+	}
+	if booster, ok := adapted[vaccine]; ok && hasAdaptedMarker(lower) {
+		return booster, nil
+	}
+	return vaccine, nil
+}
+
+func hasAdaptedMarker(text string) bool {
+	for _, marker := range adaptedMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Info returns the description of the vaccine
+func (v Vaccine) Info() (Info, bool) {
+	for _, info := range catalogue {
+		if info.ID == v {
+			return info, true
+		}
+	}
+	return Info{}, false
+}
+
+// DisplayName returns the name of the vaccine shown to the users
+func (v Vaccine) DisplayName() string {
+	if info, ok := v.Info(); ok {
+		return info.Name
+	}
+	return string(v)
+}
+
+// Family returns the family of the vaccine
+func (v Vaccine) Family() Family {
+	info, _ := v.Info()
+	return info.Family
+}
+
+// Filters returns the filters selecting the vaccine: its identifier and its
+// family. An adapted booster is also selected by the filter of its product,
+// and AnyMRNA by the filters of every mRNA product since it may be the one the
+// chat is looking for.
+func (v Vaccine) Filters() []string {
+	filters := []string{string(v)}
+	family := v.Family()
+	if family != "" && string(family) != string(v) {
+		filters = append(filters, string(family))
+	}
+	for product, booster := range adapted {
+		if booster == v {
+			filters = append(filters, string(product))
+		}
+	}
+	if v == AnyMRNA {
+		for _, info := range catalogue {
+			if info.Family == MRNA && info.ID != AnyMRNA {
+				filters = append(filters, string(info.ID))
+			}
+		}
+	}
+	return filters
+}
+
+// FilterName returns the name shown to the users for a filter, which is
+// either a vaccine or a family
+func FilterName(filter string) string {
+	if filter == string(MRNA) {
+		return "every mRNA vaccine"
+	}
+	return Vaccine(filter).DisplayName()
+}
@@ -0,0 +1,63 @@
+package vaccines
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Vaccine
+		wantErr error
+	}{
+		{text: "pfizer", want: Pfizer},
+		{text: "MRNA", want: AnyMRNA},
+		{text: "BioNTech", want: Pfizer},
+		{text: "Comirnaty Erstimpfung", want: Pfizer},
+		{text: "mRNA (BioNTech)", want: Pfizer},
+		{text: "mRNA Moderna", want: Moderna},
+		{text: "BioNTech/Moderna", want: AnyMRNA},
+		{text: "Moderna oder BioNTech", want: AnyMRNA},
+		{text: "mRNA-Impfstoff", want: AnyMRNA},
+		{text: "Comirnaty Omikron", want: PfizerAdapted},
+		{text: "Spikevax bivalent", want: ModernaAdapted},
+		{text: "AstraZeneca", want: AstraZeneca},
+		{text: "Janssen", want: JohnsonAndJohnson},
+		{text: "Nuvaxovid", want: Novavax},
+		{text: "Grippeimpfung", wantErr: ErrVaccineNotFound},
+		{text: "Pfizerstraße", wantErr: ErrVaccineNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.text, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		vaccine Vaccine
+		want    []string
+	}{
+		{vaccine: AstraZeneca, want: []string{"astra", "vector"}},
+		{vaccine: Pfizer, want: []string{"pfizer", "MRNA"}},
+		{vaccine: PfizerAdapted, want: []string{"pfizer-adapted", "MRNA", "pfizer"}},
+		{vaccine: ModernaAdapted, want: []string{"moderna-adapted", "MRNA", "moderna"}},
+		{vaccine: AnyMRNA, want: []string{"MRNA", "pfizer", "moderna", "pfizer-adapted", "moderna-adapted"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.vaccine), func(t *testing.T) {
+			if got := tt.vaccine.Filters(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filters() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
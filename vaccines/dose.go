@@ -0,0 +1,69 @@
+package vaccines
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Dose is the dose an appointment is for
+type Dose string
+
+const (
+	// DoseUnknown is an appointment the source does not tell the dose of
+	DoseUnknown Dose = ""
+	// DoseFirst is an appointment for a first dose
+	DoseFirst Dose = "first"
+	// DoseSecond is an appointment for a second dose
+	DoseSecond Dose = "second"
+	// DoseBooster is an appointment for a booster dose
+	DoseBooster Dose = "booster"
+)
+
+// ErrDoseNotFound is return when the dose can't be found
+var ErrDoseNotFound = errors.New("dose not found")
+
+// doseRegexes matches the German and English names the practices give to the
+// appointments of each dose
+var doseRegexes = map[Dose]*regexp.Regexp{
+	DoseFirst:   regexp.MustCompile(`erstimpfung|\berst-|\berste[nr]? (impfung|dosis)|\b1\.\s*(impfung|dosis)|\bfirst\b`),
+	DoseSecond:  regexp.MustCompile(`zweitimpfung|\bzweite[nr]? (impfung|dosis)|\b2\.\s*(impfung|dosis)|\bsecond\b`),
+	DoseBooster: regexp.MustCompile(`booster|auffrischung|drittimpfung|viertimpfung|\b(dritte|vierte)[nr]? (impfung|dosis)|\b[34]\.\s*(impfung|dosis)|\b(third|fourth)\b`),
+}
+
+// Doses returns the doses the chats can look for
+func Doses() []Dose {
+	return []Dose{DoseFirst, DoseSecond, DoseBooster}
+}
+
+// ClassifyDose returns the dose named in the text, like the name of the
+// appointment type of a practice. DoseUnknown is returned when the text names
+// no dose or several of them, like "Erst- und Zweitimpfung".
+func ClassifyDose(text string) Dose {
+	lower := strings.ToLower(text)
+	found := DoseUnknown
+	for _, dose := range Doses() {
+		if !doseRegexes[dose].MatchString(lower) {
+			continue
+		}
+		if found != DoseUnknown {
+			return DoseUnknown
+		}
+		found = dose
+	}
+	return found
+}
+
+// ParseDose returns the dose given by its identifier or named in the text
+func ParseDose(text string) (Dose, error) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	for _, dose := range Doses() {
+		if lower == string(dose) {
+			return dose, nil
+		}
+	}
+	if dose := ClassifyDose(lower); dose != DoseUnknown {
+		return dose, nil
+	}
+	return DoseUnknown, ErrDoseNotFound
+}
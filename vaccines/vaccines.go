@@ -1,51 +1,54 @@
 package vaccines
 
 import (
-	"errors"
-	"strings"
-)
-
-const (
-	// AstraZeneca is the name of the astrazeneca vaccine
-	AstraZeneca = "astra"
-	// JohnsonAndJohnson is the name for the JohnsonAndJohnson vaccine
-	JohnsonAndJohnson = "johnson"
-	// Pfizer is the name for the biontech/pfizer vaccine
-	Pfizer = "pfizer"
-	// Biontech is the other name for the biontech/pfizer vaccine
-	// USE ONLY PFIZER
-	biontech = "biontech"
-	// Moderna is the name for the Moderna vaccine
-	Moderna = "moderna"
-	// MRNA correspond to the pfizer or moderna
-	MRNA = "MRNA"
+	"sort"
+	"time"
 )
 
 // Result holds the information for a vaccine appointment
 type Result struct {
-	VaccineName string
-	Amount      int64
-	Message     string
+	// Source is the name of the source the result comes from
+	Source string
+	// Practice is the name of the practice offering the appointments
+	Practice  string
+	Address   string
+	District  string
+	Latitude  float64
+	Longitude float64
+	// BookingURL is where to book the appointments
+	BookingURL string
+	// Phone is the number to call to book the appointments
+	Phone   string
+	Vaccine Vaccine
+	Dose    Dose
+	// Amount is the number of free slots, zero when the source can't count them
+	Amount int64
+	// Detail is a free text shown along with the appointments
+	Detail string
+	// Slots are the free slots sorted by time, empty when the source can't list them
+	Slots []time.Time
+	// DateOnly is set when the source only knows the day of the slots
+	DateOnly     bool
+	EarliestSlot time.Time
+	LatestSlot   time.Time
+	FetchedAt    time.Time
 }
 
-// ErrVaccineNotFound is return when the vaccine can't be found
-var ErrVaccineNotFound = errors.New("vaccine not found")
-
-var vaccines = []string{
-	AstraZeneca,
-	JohnsonAndJohnson,
-	MRNA,
+// SetSlots sorts the slots and sets them along with the earliest and latest slot
+func (r *Result) SetSlots(slots []time.Time) {
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Before(slots[j]) })
+	r.Slots = slots
+	if len(slots) == 0 {
+		r.EarliestSlot = time.Time{}
+		r.LatestSlot = time.Time{}
+		return
+	}
+	r.EarliestSlot = slots[0]
+	r.LatestSlot = slots[len(slots)-1]
 }
 
-func GetVaccineName(name string) (string, error) {
-	for _, vaccine := range vaccines {
-		if strings.Contains(strings.ToLower(name), vaccine) ||
-			(vaccine == MRNA &&
-				strings.Contains(strings.ToLower(name), biontech) ||
-				strings.Contains(strings.ToLower(name), Pfizer) ||
-				strings.Contains(strings.ToLower(name), Moderna)) {
-			return vaccine, nil
-		}
-	}
-	return "", ErrVaccineNotFound
+// TimeUnknown reports whether the source can't tell the time of the slots,
+// either because it only knows their day or because it lists no slot
+func (r *Result) TimeUnknown() bool {
+	return r.DateOnly || len(r.Slots) == 0
 }